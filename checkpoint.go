@@ -0,0 +1,107 @@
+package devtoolkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// Checkpoint is the position of the last item processed by a checkpointed iteration.
+type Checkpoint struct {
+	// Line is the number of the last processed line, or 0 if none.
+	Line int
+
+	// Offset is the byte offset where the read resumes, for readers that can seek to it.
+	Offset int64
+}
+
+// SaveCheckpoint persists the checkpoint of an iteration, so it can be resumed after an interruption.
+type SaveCheckpoint func(context.Context, Checkpoint) error
+
+// CheckpointOptions holds options for Checkpointed.
+type CheckpointOptions struct {
+	// Every is the number of processed items between saves. Default is 1.
+	Every int
+}
+
+// WithCheckpointEvery sets the number of processed items between saves.
+func WithCheckpointEvery(n int) func(*CheckpointOptions) {
+	return func(opts *CheckpointOptions) {
+		opts.Every = n
+	}
+}
+
+// Checkpointed returns a sequence over the items of seq after the checkpoint from, skipping those whose position,
+// returned by position, is at or before from.Line. An item is processed once the loop body completes for it without
+// breaking, and the checkpoint of the last processed item is passed to save every CheckpointOptions.Every items and
+// when the iteration ends, so an interrupted iteration restarts where it left off.
+// The iteration stops after the first error, from seq, save or ctx, which is yielded with a zero item.
+// If the loop breaks, the checkpoint is still saved, but a failure to save it can't be reported, in which case the
+// items processed since the previous save are processed again when resuming.
+func Checkpointed[T any](
+	ctx context.Context,
+	seq iter.Seq2[T, error],
+	from Checkpoint,
+	position func(T) Checkpoint,
+	save SaveCheckpoint,
+	optFns ...func(*CheckpointOptions),
+) iter.Seq2[T, error] {
+	var opts = &CheckpointOptions{Every: 1}
+	for _, fn := range optFns {
+		fn(opts)
+	}
+	if opts.Every <= 0 {
+		opts.Every = 1
+	}
+
+	return func(yield func(T, error) bool) {
+		var zero T
+		var last = from
+		var pending int
+
+		// flush saves the checkpoint of the last processed item, if not saved yet
+		var flush = func() error {
+			if pending == 0 {
+				return nil
+			}
+			pending = 0
+			if err := save(ctx, last); err != nil {
+				return fmt.Errorf("error saving checkpoint at line %d: %w", last.Line, err)
+			}
+			return nil
+		}
+
+		for item, err := range seq {
+			if err == nil {
+				err = ctx.Err()
+			}
+			if err != nil {
+				yield(zero, errors.Join(err, flush()))
+				return
+			}
+
+			pos := position(item)
+			if pos.Line <= from.Line {
+				continue
+			}
+
+			if !yield(item, nil) {
+				_ = flush()
+				return
+			}
+
+			last = pos
+			if pending++; pending >= opts.Every {
+				if err := flush(); err != nil {
+					yield(zero, err)
+					return
+				}
+			}
+		}
+
+		if err := flush(); err != nil {
+			yield(zero, err)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package devtoolkit
+
+import (
+	"cmp"
+	"golang.org/x/exp/constraints"
+)
+
+// Comparator compares two values, returning a negative number if a < b, zero if a == b and a positive number if a > b.
+// Comparators can be chained for multi-key comparisons and used with SortBy and NewPriorityQueue through Less,
+// or directly with BinarySearchBy, SortedInsertBy and slices.SortFunc.
+type Comparator[T any] func(a, b T) int
+
+// NaturalOrder returns a Comparator of ordered values in ascending order.
+func NaturalOrder[T constraints.Ordered]() Comparator[T] {
+	return cmp.Compare[T]
+}
+
+// Comparing returns a Comparator that compares values by the ordered key returned by keyFn, in ascending order.
+func Comparing[T any, K constraints.Ordered](keyFn func(T) K) Comparator[T] {
+	return func(a, b T) int {
+		return cmp.Compare(keyFn(a), keyFn(b))
+	}
+}
+
+// ComparingWith returns a Comparator that compares values by the key returned by keyFn, using keyCmp.
+func ComparingWith[T, K any](keyFn func(T) K, keyCmp Comparator[K]) Comparator[T] {
+	return func(a, b T) int {
+		return keyCmp(keyFn(a), keyFn(b))
+	}
+}
+
+// ThenComparing returns a Comparator that compares with c and, for values c considers equal, with next.
+func (c Comparator[T]) ThenComparing(next Comparator[T]) Comparator[T] {
+	return func(a, b T) int {
+		if r := c(a, b); r != 0 {
+			return r
+		}
+		return next(a, b)
+	}
+}
+
+// Reversed returns a Comparator imposing the reverse order of c.
+func (c Comparator[T]) Reversed() Comparator[T] {
+	return func(a, b T) int {
+		return c(b, a)
+	}
+}
+
+// Less returns a less function reporting whether a < b according to c, for SortBy, SortStableBy and NewPriorityQueue.
+func (c Comparator[T]) Less() func(a, b T) bool {
+	return func(a, b T) bool {
+		return c(a, b) < 0
+	}
+}
@@ -75,8 +75,11 @@ func (ce *ConcurrentExec) init(ctx context.Context, totalFns int) {
 
 func (ce *ConcurrentExec) executorWorker(pos int, fn ConcurrentFn) {
 	defer ce.concurrencyWg.Done()
-	result, err := fn(ce.concurrencyCtx)
-	ce.errs[pos] = err
+	var result any
+	ce.errs[pos] = callWithPanicReport(ce.concurrencyCtx, func() (err error) {
+		result, err = fn(ce.concurrencyCtx)
+		return err
+	})
 	val := reflect.ValueOf(result)
 
 	// if result is not pointer
@@ -0,0 +1,57 @@
+package devtoolkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+type stageResultsCtxKey struct{}
+
+// ExecuteStaged executes the stages in order, running the fns of each stage concurrently and starting the next stage
+// only after all the fns of the previous one are done (fan-out/fan-in).
+// The fns of a stage can access the results of the previous stages with StageResults.
+// If any fn of a stage fails, the next stages are not executed.
+// Returns the results of the executed stages, indexed by stage and fn, and the joined errors of the failed stage.
+func (ce *ConcurrentExec) ExecuteStaged(ctx context.Context, stages [][]ConcurrentFn) ([][]any, error) {
+	if ctx == nil {
+		return nil, ConcurrentExecNilContextErr
+	}
+
+	if len(stages) == 0 {
+		return nil, ConcurrentExecFnsNilOrEmptyErr
+	}
+
+	var results = make([][]any, 0, len(stages))
+	for i, fns := range stages {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+
+		stageCtx := context.WithValue(ctx, stageResultsCtxKey{}, results[:len(results):len(results)])
+		resp, err := ce.ExecuteFns(stageCtx, fns...)
+		if err != nil {
+			return results, fmt.Errorf("stage %d: %w", i, err)
+		}
+
+		results = append(results, resp.Results())
+
+		var errs []error
+		for j, err := range resp.Errors() {
+			if err != nil {
+				errs = append(errs, fmt.Errorf("stage %d: fn %d: %w", i, j, err))
+			}
+		}
+		if len(errs) > 0 {
+			return results, errors.Join(errs...)
+		}
+	}
+	return results, nil
+}
+
+// StageResults returns the results of the stages completed before the current one, indexed by stage and fn,
+// when called from a fn executed by ConcurrentExec.ExecuteStaged. Returns nil otherwise.
+func StageResults(ctx context.Context) [][]any {
+	results, _ := ctx.Value(stageResultsCtxKey{}).([][]any)
+	return results
+}
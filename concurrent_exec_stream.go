@@ -0,0 +1,60 @@
+package devtoolkit
+
+import "context"
+
+// TypedConcurrentFn represents a function that can be executed concurrently, returning a typed result and an error.
+type TypedConcurrentFn[R any] func(ctx context.Context) (R, error)
+
+// ConcurrentResult is the result of a TypedConcurrentFn, along with its index in the executed fns.
+type ConcurrentResult[R any] struct {
+	Index int
+	Value R
+	Err   error
+}
+
+// StreamConcurrentFns executes fns concurrently using ConcurrentExec and delivers each result to the returned channel
+// as soon as its fn completes, so downstream processing overlaps with the remaining executions.
+// The channel is buffered to hold all the results and is closed when all fns are done.
+func StreamConcurrentFns[R any](ctx context.Context, fns ...TypedConcurrentFn[R]) (<-chan ConcurrentResult[R], error) {
+	var out = make(chan ConcurrentResult[R], len(fns))
+	if err := StreamConcurrentFnsTo(ctx, out, fns...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StreamConcurrentFnsTo executes fns concurrently using ConcurrentExec and sends each result to out as soon as
+// its fn completes. out is closed when all fns are done.
+// If ctx is done before a result can be sent, the result is dropped so no goroutine is left blocked.
+func StreamConcurrentFnsTo[R any](ctx context.Context, out chan<- ConcurrentResult[R], fns ...TypedConcurrentFn[R]) error {
+	if ctx == nil {
+		return ConcurrentExecNilContextErr
+	}
+
+	if len(fns) == 0 {
+		return ConcurrentExecFnsNilOrEmptyErr
+	}
+
+	var wrapped = make([]ConcurrentFn, len(fns))
+	for i, fn := range fns {
+		wrapped[i] = func(ctx context.Context) (any, error) {
+			value, err := fn(ctx)
+			select {
+			case out <- ConcurrentResult[R]{Index: i, Value: value, Err: err}:
+			case <-ctx.Done():
+			}
+			return nil, err
+		}
+	}
+
+	resp, err := NewConcurrentExec().ExecuteFns(ctx, wrapped...)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		<-resp.Done()
+		close(out)
+	}()
+	return nil
+}
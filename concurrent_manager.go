@@ -43,9 +43,35 @@ type ConcurrentManager struct {
 	wg sync.WaitGroup
 }
 
+// ConcurrentManagerOptions holds the options of a ConcurrentManager.
+type ConcurrentManagerOptions struct {
+	MinWorkers         int           // indicates the minimum number of workers. Default is 1.
+	MaxWorkers         int           // indicates the maximum number of workers. Default is MinWorkers.
+	WorkerIncreaseRate float64       // indicates the rate at which the number of workers is increased, must be greater than 1. Default is 1.5.
+	TimeIncreasePeriod time.Duration // indicates the period after which the number of workers is potentially increased. Default is 1s.
+}
+
 // NewConcurrentManager creates a new instance of ConcurrentManager with specified parameters.
 // It ensures that the provided parameters are within acceptable ranges and initializes the manager.
 func NewConcurrentManager(minWorkers, maxWorkers int, workerIncreaseRate float64, timeIncreasePeriod time.Duration) *ConcurrentManager {
+	return NewConcurrentManagerWithOptions(
+		WithWorkers(minWorkers, maxWorkers),
+		WithWorkerIncreaseRate(workerIncreaseRate),
+		WithTimeIncreasePeriod(timeIncreasePeriod),
+	)
+}
+
+// NewConcurrentManagerWithOptions creates a new instance of ConcurrentManager with the defaults modified by the given
+// option functions, e.g. NewConcurrentManagerWithOptions(WithWorkers(2, 10)).
+func NewConcurrentManagerWithOptions(optFns ...func(*ConcurrentManagerOptions)) *ConcurrentManager {
+	var opts = &ConcurrentManagerOptions{}
+	for _, fn := range optFns {
+		fn(opts)
+	}
+
+	var minWorkers, maxWorkers = opts.MinWorkers, opts.MaxWorkers
+	var workerIncreaseRate, timeIncreasePeriod = opts.WorkerIncreaseRate, opts.TimeIncreasePeriod
+
 	if minWorkers == 0 {
 		minWorkers = 1
 	}
@@ -73,6 +99,28 @@ func NewConcurrentManager(minWorkers, maxWorkers int, workerIncreaseRate float64
 	return cw
 }
 
+// WithWorkers sets ConcurrentManagerOptions.MinWorkers and ConcurrentManagerOptions.MaxWorkers.
+func WithWorkers(minWorkers, maxWorkers int) func(*ConcurrentManagerOptions) {
+	return func(o *ConcurrentManagerOptions) {
+		o.MinWorkers = minWorkers
+		o.MaxWorkers = maxWorkers
+	}
+}
+
+// WithWorkerIncreaseRate sets ConcurrentManagerOptions.WorkerIncreaseRate.
+func WithWorkerIncreaseRate(rate float64) func(*ConcurrentManagerOptions) {
+	return func(o *ConcurrentManagerOptions) {
+		o.WorkerIncreaseRate = rate
+	}
+}
+
+// WithTimeIncreasePeriod sets ConcurrentManagerOptions.TimeIncreasePeriod.
+func WithTimeIncreasePeriod(period time.Duration) func(*ConcurrentManagerOptions) {
+	return func(o *ConcurrentManagerOptions) {
+		o.TimeIncreasePeriod = period
+	}
+}
+
 // Allocate requests a new worker to be allocated.
 // It blocks if the maximum number of workers has been reached, until a worker is released.
 func (c *ConcurrentManager) Allocate() {
@@ -0,0 +1,230 @@
+package devtoolkit
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+	"math"
+	"reflect"
+	"sync"
+)
+
+// concurrentMapShards is the number of shards of a ConcurrentMap, each guarded by its own lock.
+const concurrentMapShards = 32
+
+// concurrentMapSeed is the seed of the key hashes, shared by all the maps so the zero value is usable.
+var concurrentMapSeed = maphash.MakeSeed()
+
+// ConcurrentMap is a type-safe map safe for concurrent use, sharded by key hash into maps guarded by their own
+// sync.RWMutex, so operations on different keys rarely contend on the same lock.
+// Unlike sync.Map, it supports atomic read-modify-write operations with Compute and reports its length in constant time.
+// The zero value is an empty map ready to use.
+type ConcurrentMap[K comparable, V any] struct {
+	shards [concurrentMapShards]concurrentMapShard[K, V]
+}
+
+// concurrentMapShard is a shard of a ConcurrentMap.
+type concurrentMapShard[K comparable, V any] struct {
+	m  map[K]V
+	mu sync.RWMutex
+}
+
+// NewConcurrentMap returns a new empty ConcurrentMap.
+func NewConcurrentMap[K comparable, V any]() *ConcurrentMap[K, V] {
+	return &ConcurrentMap[K, V]{}
+}
+
+// Load returns the value stored for key and true, or the zero value and false if key is not present.
+func (cm *ConcurrentMap[K, V]) Load(key K) (V, bool) {
+	shard := cm.shard(key)
+	shard.mu.RLock()
+	defer shard.mu.RUnlock()
+	v, ok := shard.m[key]
+	return v, ok
+}
+
+// Store sets the value for key.
+func (cm *ConcurrentMap[K, V]) Store(key K, value V) {
+	shard := cm.shard(key)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	shard.init()
+	shard.m[key] = value
+}
+
+// LoadOrStore returns the existing value for key and true if present.
+// Otherwise, it stores and returns value and false.
+func (cm *ConcurrentMap[K, V]) LoadOrStore(key K, value V) (V, bool) {
+	shard := cm.shard(key)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	if v, ok := shard.m[key]; ok {
+		return v, true
+	}
+	shard.init()
+	shard.m[key] = value
+	return value, false
+}
+
+// Delete removes key from the map.
+func (cm *ConcurrentMap[K, V]) Delete(key K) {
+	shard := cm.shard(key)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	delete(shard.m, key)
+}
+
+// LoadAndDelete removes key from the map, returning its previous value and true if it was present.
+func (cm *ConcurrentMap[K, V]) LoadAndDelete(key K) (V, bool) {
+	shard := cm.shard(key)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	v, ok := shard.m[key]
+	delete(shard.m, key)
+	return v, ok
+}
+
+// Compute atomically updates the value of key with fn, which receives the current value and whether key is present,
+// and returns the new value and whether to keep it. If keep is false, key is removed.
+// Returns the new value and keep. fn must not call methods of the map.
+func (cm *ConcurrentMap[K, V]) Compute(key K, fn func(value V, loaded bool) (newValue V, keep bool)) (V, bool) {
+	shard := cm.shard(key)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	v, ok := shard.m[key]
+	newValue, keep := fn(v, ok)
+	if !keep {
+		delete(shard.m, key)
+		return newValue, false
+	}
+
+	shard.init()
+	shard.m[key] = newValue
+	return newValue, true
+}
+
+// Range calls fn for each key and value of the map until fn returns false.
+// fn runs over a snapshot taken at the beginning of the call, so it can safely call methods of the map.
+// The shards are copied one after another, so the snapshot may miss concurrent updates of the shards copied first.
+func (cm *ConcurrentMap[K, V]) Range(fn func(key K, value V) bool) {
+	var entries []Pair[K, V]
+	for i := range cm.shards {
+		shard := &cm.shards[i]
+		shard.mu.RLock()
+		entries = append(entries, MapEntries(shard.m)...)
+		shard.mu.RUnlock()
+	}
+
+	for _, e := range entries {
+		if !fn(e.First, e.Second) {
+			return
+		}
+	}
+}
+
+// Len returns the number of keys in the map. The shards are counted one after another, so concurrent updates may
+// be partially counted.
+func (cm *ConcurrentMap[K, V]) Len() int {
+	var n int
+	for i := range cm.shards {
+		shard := &cm.shards[i]
+		shard.mu.RLock()
+		n += len(shard.m)
+		shard.mu.RUnlock()
+	}
+	return n
+}
+
+// shard returns the shard holding key.
+func (cm *ConcurrentMap[K, V]) shard(key K) *concurrentMapShard[K, V] {
+	return &cm.shards[hashKey(key)%concurrentMapShards]
+}
+
+// init lazily allocates the map, so the zero value is usable. Must be called with the write lock held.
+func (s *concurrentMapShard[K, V]) init() {
+	if s.m == nil {
+		s.m = make(map[K]V)
+	}
+}
+
+// hashKey returns the hash of key, equal for equal keys.
+// Strings and integers are hashed directly; other comparable types, such as structs, through reflection.
+func hashKey[K comparable](key K) uint64 {
+	switch k := any(key).(type) {
+	case string:
+		return maphash.String(concurrentMapSeed, k)
+	case int:
+		return mixUint64(uint64(k))
+	case int64:
+		return mixUint64(uint64(k))
+	case int32:
+		return mixUint64(uint64(k))
+	case uint:
+		return mixUint64(uint64(k))
+	case uint64:
+		return mixUint64(k)
+	case uint32:
+		return mixUint64(uint64(k))
+	}
+
+	var h maphash.Hash
+	h.SetSeed(concurrentMapSeed)
+	writeHashValue(&h, reflect.ValueOf(key))
+	return h.Sum64()
+}
+
+// writeHashValue writes the comparable value v to h, so that equal values write the same bytes.
+func writeHashValue(h *maphash.Hash, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.String:
+		_, _ = h.WriteString(v.String())
+	case reflect.Bool:
+		writeHashUint64(h, uint64(IfThenElse(v.Bool(), 1, 0)))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeHashUint64(h, uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeHashUint64(h, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeHashFloat(h, v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		writeHashFloat(h, real(v.Complex()))
+		writeHashFloat(h, imag(v.Complex()))
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		writeHashUint64(h, uint64(v.Pointer()))
+	case reflect.Interface:
+		if !v.IsNil() {
+			writeHashValue(h, v.Elem())
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			writeHashValue(h, v.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			writeHashValue(h, v.Field(i))
+		}
+	}
+}
+
+func writeHashFloat(h *maphash.Hash, f float64) {
+	if f == 0 {
+		f = 0 // -0 == +0
+	}
+	writeHashUint64(h, math.Float64bits(f))
+}
+
+func writeHashUint64(h *maphash.Hash, u uint64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], u)
+	_, _ = h.Write(buf[:])
+}
+
+// mixUint64 spreads the bits of u, so consecutive integers fall into different shards (splitmix64 finalizer).
+func mixUint64(u uint64) uint64 {
+	u ^= u >> 30
+	u *= 0xbf58476d1ce4e5b9
+	u ^= u >> 27
+	u *= 0x94d049bb133111eb
+	u ^= u >> 31
+	return u
+}
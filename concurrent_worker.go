@@ -1,6 +1,10 @@
 package devtoolkit
 
-import "sync"
+import (
+	"context"
+	"fmt"
+	"sync"
+)
 
 func NewConcurrentWorkers(maxWorkers int) *ConcurrentWorkers {
 	return &ConcurrentWorkers{
@@ -19,9 +23,12 @@ type ConcurrentWorkers struct {
 	mu         sync.Mutex
 }
 
+// Execute runs fn in a worker, blocking until one is available. It does nothing if the workers are stopped.
+// If fn panics, the panic is recovered and the workers are stopped with an error wrapping a *PanicReport.
 func (cw *ConcurrentWorkers) Execute(fn func()) {
 	cw.mu.Lock()
 	if cw.closed {
+		cw.mu.Unlock()
 		return
 	}
 	cw.ch <- struct{}{}
@@ -29,11 +36,16 @@ func (cw *ConcurrentWorkers) Execute(fn func()) {
 
 	cw.wg.Add(1)
 	go func() {
-		defer func() {
-			cw.wg.Done()
-			<-cw.ch
-		}()
-		fn()
+		defer cw.wg.Done()
+		err := callWithPanicReport(context.Background(), func() error {
+			fn()
+			return nil
+		})
+		// release the worker before stopping, since Execute may hold the lock while waiting for it.
+		<-cw.ch
+		if err != nil {
+			cw.close(fmt.Errorf("concurrent worker: %w", err))
+		}
 	}()
 }
 
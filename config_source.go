@@ -0,0 +1,214 @@
+package devtoolkit
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+const (
+	defaultConfigSourcePollInterval = 30 * time.Second
+	defaultHTTPConfigSourceMaxSize  = 10 << 20 // 10MiB
+)
+
+// ConfigFormat is the format of the contents of a configuration source.
+type ConfigFormat int
+
+const (
+	ConfigFormatYAML ConfigFormat = iota // YAML format
+	ConfigFormatJSON                     // JSON format
+)
+
+// ConfigSource is a source of configuration contents, such as a file or a config service
+// (Consul, Spring Cloud Config, ...).
+type ConfigSource interface {
+	// Fetch returns the current contents of the source and their format.
+	Fetch(ctx context.Context) ([]byte, ConfigFormat, error)
+
+	// Watch returns a channel receiving the new contents of the source each time they change.
+	// The channel is closed when ctx is done.
+	Watch(ctx context.Context) <-chan []byte
+}
+
+// LoadPropFromSource fetches the contents of source and loads them into the provided props,
+// with the same environment variable expansion, migrations, validations, defaults and post load hooks as LoadPropFile.
+func LoadPropFromSource(ctx context.Context, source ConfigSource, props []ToolKitProp) error {
+	data, format, err := source.Fetch(ctx)
+	if err != nil {
+		return fmt.Errorf("error fetching config source: %w", err)
+	}
+	return LoadPropBytes(data, format, props)
+}
+
+// LoadPropBytes loads configuration contents of the given format into the provided props,
+// with the same environment variable expansion, migrations, validations, defaults and post load hooks as LoadPropFile.
+// It can be used to reload props with the contents received from ConfigSource.Watch.
+func LoadPropBytes(data []byte, format ConfigFormat, props []ToolKitProp) error {
+	var fileType configFileType
+	switch format {
+	case ConfigFormatYAML:
+		fileType = ymlType
+	case ConfigFormatJSON:
+		fileType = jsonType
+	default:
+		return fmt.Errorf("invalid config format '%d'", format)
+	}
+
+	return loadProps([]byte(os.ExpandEnv(string(data))), fileType, props)
+}
+
+// FileConfigSource is a ConfigSource reading a YAML or JSON file.
+type FileConfigSource struct {
+	path         string
+	pollInterval time.Duration
+}
+
+// NewFileConfigSource returns a new FileConfigSource for the file at path,
+// checked for changes every pollInterval when watched (30s if not positive).
+func NewFileConfigSource(path string, pollInterval time.Duration) *FileConfigSource {
+	if pollInterval <= 0 {
+		pollInterval = defaultConfigSourcePollInterval
+	}
+	return &FileConfigSource{path: path, pollInterval: pollInterval}
+}
+
+// Fetch returns the contents of the file, with the format given by its extension.
+func (s *FileConfigSource) Fetch(_ context.Context) ([]byte, ConfigFormat, error) {
+	fileType, err := getConfigFileType(s.path)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if fileType == jsonType {
+		return data, ConfigFormatJSON, nil
+	}
+	return data, ConfigFormatYAML, nil
+}
+
+// Watch returns a channel receiving the contents of the file each time they change.
+// Read errors are ignored until the next poll.
+func (s *FileConfigSource) Watch(ctx context.Context) <-chan []byte {
+	return pollConfigSource(ctx, s, s.pollInterval)
+}
+
+// HTTPConfigSource is a ConfigSource fetching the configuration with a GET request to a URL.
+type HTTPConfigSource struct {
+	// Client is the HTTP client used for the requests, http.DefaultClient if nil.
+	Client *http.Client
+
+	// Header holds the headers sent on each request, e.g. authorization tokens.
+	Header http.Header
+
+	// MaxSize is the maximum size in bytes of the response body, 10MiB if not positive.
+	// Larger bodies fail with ErrReadLimitExceeded.
+	MaxSize int64
+
+	url          string
+	format       ConfigFormat
+	pollInterval time.Duration
+}
+
+// NewHTTPConfigSource returns a new HTTPConfigSource for the given URL serving contents in the given format,
+// polled for changes every pollInterval when watched (30s if not positive).
+func NewHTTPConfigSource(url string, format ConfigFormat, pollInterval time.Duration) *HTTPConfigSource {
+	if pollInterval <= 0 {
+		pollInterval = defaultConfigSourcePollInterval
+	}
+	return &HTTPConfigSource{
+		Header:       make(http.Header),
+		url:          url,
+		format:       format,
+		pollInterval: pollInterval,
+	}
+}
+
+// Fetch returns the body of a GET request to the URL.
+// Returns an error if the response status is not 2xx or the body is larger than MaxSize.
+func (s *HTTPConfigSource) Fetch(ctx context.Context) ([]byte, ConfigFormat, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	for k, v := range s.Header {
+		req.Header[k] = v
+	}
+
+	var client = s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, 0, fmt.Errorf("unexpected status fetching '%s': %s", s.url, resp.Status)
+	}
+
+	var maxSize = s.MaxSize
+	if maxSize <= 0 {
+		maxSize = defaultHTTPConfigSourceMaxSize
+	}
+
+	data, err := io.ReadAll(LimitReaderWithErr(resp.Body, maxSize))
+	if err != nil {
+		return nil, 0, fmt.Errorf("error reading response of '%s': %w", s.url, err)
+	}
+	return data, s.format, nil
+}
+
+// Watch returns a channel receiving the body of the URL each time it changes.
+// Request errors are ignored until the next poll.
+func (s *HTTPConfigSource) Watch(ctx context.Context) <-chan []byte {
+	return pollConfigSource(ctx, s, s.pollInterval)
+}
+
+// pollConfigSource fetches source every interval and sends its contents when they differ from the last ones.
+// The first successful fetch sets the initial contents and is not sent.
+func pollConfigSource(ctx context.Context, source ConfigSource, interval time.Duration) <-chan []byte {
+	var ch = make(chan []byte)
+	go func() {
+		defer close(ch)
+
+		last, _, err := source.Fetch(ctx)
+		var initialized = err == nil
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			data, _, err := source.Fetch(ctx)
+			if err != nil {
+				continue
+			}
+			if initialized && bytes.Equal(data, last) {
+				continue
+			}
+			last, initialized = data, true
+
+			select {
+			case ch <- data:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
@@ -0,0 +1,255 @@
+package devtoolkit
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// CopyConverter converts the values of a source type into a destination type when copying structs.
+// Create it with NewCopyConverter.
+type CopyConverter struct {
+	from, to reflect.Type
+	fn       func(any) (any, error)
+}
+
+// NewCopyConverter returns a CopyConverter applied to the source fields of type S copied to destination fields of type D.
+func NewCopyConverter[S, D any](fn func(S) (D, error)) CopyConverter {
+	return CopyConverter{
+		from: reflect.TypeFor[S](),
+		to:   reflect.TypeFor[D](),
+		fn: func(v any) (any, error) {
+			return fn(v.(S))
+		},
+	}
+}
+
+// CopyOptions holds options for CopyStruct.
+type CopyOptions struct {
+	// FieldMapping maps source field paths to destination field names, e.g. {"Zip": "PostalCode", "Address.Nr": "Number"}.
+	// Nested fields are identified by their dot-separated path, where slice items share the path of the slice.
+	// Unmapped fields are copied to the fields with the same name.
+	FieldMapping map[string]string
+
+	// Ignore lists the source field paths that are not copied.
+	Ignore []string
+
+	// Converters are the conversions applied to the fields whose types match, before any other rule.
+	Converters []CopyConverter
+
+	// DeepCopy indicates whether pointers, slices and maps are copied instead of shared with the source.
+	// Default is false.
+	DeepCopy bool
+}
+
+// CopyStruct copies the exported fields of src, a struct or a pointer to a struct, into the fields with the same name
+// (or the one given by CopyOptions.FieldMapping) of dst, a non-nil pointer to a struct. Source fields without a
+// destination field are skipped.
+// Fields are copied when the types are assignable, with a converter, or by converting numbers and types with the same
+// underlying type. Nested structs, pointers to them and slices of different types are copied field by field and item
+// by item. Returns an error wrapping ErrNotStruct if dst or src are not structs, or an error if a field can't be copied.
+func CopyStruct(dst, src any, opts CopyOptions) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: dst must be a non-nil pointer to a struct", ErrNotStruct)
+	}
+
+	sv := reflect.ValueOf(src)
+	for sv.Kind() == reflect.Pointer && !sv.IsNil() {
+		sv = sv.Elem()
+	}
+	if sv.Kind() != reflect.Struct {
+		return fmt.Errorf("%w: src must be a struct or a non-nil pointer to a struct", ErrNotStruct)
+	}
+
+	var c = &structCopier{opts: opts, ignore: make(map[string]struct{}, len(opts.Ignore))}
+	for _, path := range opts.Ignore {
+		c.ignore[path] = struct{}{}
+	}
+	return c.copyStruct(dv.Elem(), sv, "")
+}
+
+// structCopier copies structs with the given options.
+type structCopier struct {
+	opts   CopyOptions
+	ignore map[string]struct{}
+}
+
+// copyStruct copies the fields of the struct src into the settable struct dst.
+// prefix is the path of src, empty for the root struct.
+func (c *structCopier) copyStruct(dst, src reflect.Value, prefix string) error {
+	var srcType = src.Type()
+	for i := 0; i < srcType.NumField(); i++ {
+		field := srcType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		path := prefix + field.Name
+		if _, ok := c.ignore[path]; ok {
+			continue
+		}
+
+		name := field.Name
+		if mapped, ok := c.opts.FieldMapping[path]; ok {
+			name = mapped
+		}
+
+		dstField, ok := dst.Type().FieldByName(name)
+		if !ok || !dstField.IsExported() {
+			continue
+		}
+		df, err := dst.FieldByIndexErr(dstField.Index)
+		if err != nil {
+			continue
+		}
+
+		if err := c.copyValue(df, src.Field(i), path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyValue copies src into the settable value dst. path is the path of src, used for nested structs and errors.
+func (c *structCopier) copyValue(dst, src reflect.Value, path string) error {
+	for _, conv := range c.opts.Converters {
+		if conv.from == src.Type() && conv.to == dst.Type() {
+			v, err := conv.fn(src.Interface())
+			if err != nil {
+				return fmt.Errorf("field '%s': %w", path, err)
+			}
+			if rv := reflect.ValueOf(v); rv.IsValid() {
+				dst.Set(rv)
+			} else {
+				dst.SetZero()
+			}
+			return nil
+		}
+	}
+
+	switch {
+	case src.Type().AssignableTo(dst.Type()):
+		if c.opts.DeepCopy {
+			src = deepCopyValue(src)
+		}
+		dst.Set(src)
+		return nil
+
+	case src.Kind() == reflect.Pointer:
+		if src.IsNil() {
+			dst.SetZero()
+			return nil
+		}
+		return c.copyValue(dst, src.Elem(), path)
+
+	case dst.Kind() == reflect.Pointer:
+		var elem = reflect.New(dst.Type().Elem())
+		if err := c.copyValue(elem.Elem(), src, path); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+
+	case src.Kind() == reflect.Struct && dst.Kind() == reflect.Struct:
+		return c.copyStruct(dst, src, path+".")
+
+	case src.Kind() == reflect.Slice && dst.Kind() == reflect.Slice:
+		if src.IsNil() {
+			dst.SetZero()
+			return nil
+		}
+		var items = reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			if err := c.copyValue(items.Index(i), src.Index(i), path); err != nil {
+				return err
+			}
+		}
+		dst.Set(items)
+		return nil
+
+	case isConvertible(src.Type(), dst.Type()):
+		dst.Set(src.Convert(dst.Type()))
+		return nil
+
+	default:
+		return fmt.Errorf("field '%s': cannot copy %s to %s", path, src.Type(), dst.Type())
+	}
+}
+
+// isConvertible returns true if values of type from can be converted to type to without changing their meaning:
+// between numbers, or between types with the same underlying type.
+func isConvertible(from, to reflect.Type) bool {
+	if !from.ConvertibleTo(to) {
+		return false
+	}
+	if isNumberKind(from.Kind()) && isNumberKind(to.Kind()) {
+		return true
+	}
+	return from.Kind() == to.Kind() && from.Kind() != reflect.Struct
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// deepCopyValue returns a copy of v that shares no pointers, slices or maps with it.
+// Unexported struct fields are copied as they are.
+func deepCopyValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		var p = reflect.New(v.Type().Elem())
+		p.Elem().Set(deepCopyValue(v.Elem()))
+		return p
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		var s = reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			s.Index(i).Set(deepCopyValue(v.Index(i)))
+		}
+		return s
+
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		var m = reflect.MakeMapWithSize(v.Type(), v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			m.SetMapIndex(iter.Key(), deepCopyValue(iter.Value()))
+		}
+		return m
+
+	case reflect.Struct:
+		var s = reflect.New(v.Type()).Elem()
+		s.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				s.Field(i).Set(deepCopyValue(v.Field(i)))
+			}
+		}
+		return s
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		var i = reflect.New(v.Type()).Elem()
+		i.Set(deepCopyValue(v.Elem()))
+		return i
+
+	default:
+		return v
+	}
+}
@@ -0,0 +1,124 @@
+package devtoolkit
+
+import "sort"
+
+// Counter is a multiset counting the occurrences of comparable items.
+// Only items with a positive count are kept.
+// The zero value is an empty counter ready to use. A Counter is not safe for concurrent use.
+type Counter[T comparable] struct {
+	counts map[T]*counterEntry
+	total  int
+	seq    int
+}
+
+type counterEntry struct {
+	count int
+	seq   int // first insertion order, used to break ties
+}
+
+// NewCounter returns a new Counter with one occurrence of each of the given items.
+func NewCounter[T comparable](items ...T) *Counter[T] {
+	var c = &Counter[T]{counts: make(map[T]*counterEntry)}
+	c.Add(items...)
+	return c
+}
+
+// Add adds one occurrence of each of the given items.
+func (c *Counter[T]) Add(items ...T) {
+	for _, item := range items {
+		c.AddN(item, 1)
+	}
+}
+
+// AddN adds n occurrences of item. A negative n subtracts occurrences.
+func (c *Counter[T]) AddN(item T, n int) {
+	if n < 0 {
+		c.SubtractN(item, -n)
+		return
+	}
+	if n == 0 {
+		return
+	}
+
+	if c.counts == nil {
+		c.counts = make(map[T]*counterEntry)
+	}
+	e, ok := c.counts[item]
+	if !ok {
+		e = &counterEntry{seq: c.seq}
+		c.counts[item] = e
+		c.seq++
+	}
+	e.count += n
+	c.total += n
+}
+
+// Subtract removes one occurrence of each of the given items, if present.
+func (c *Counter[T]) Subtract(items ...T) {
+	for _, item := range items {
+		c.SubtractN(item, 1)
+	}
+}
+
+// SubtractN removes n occurrences of item, removing the item when its count reaches zero.
+func (c *Counter[T]) SubtractN(item T, n int) {
+	e, ok := c.counts[item]
+	if !ok || n <= 0 {
+		return
+	}
+
+	n = min(n, e.count)
+	e.count -= n
+	c.total -= n
+	if e.count == 0 {
+		delete(c.counts, item)
+	}
+}
+
+// Count returns the number of occurrences of item.
+func (c *Counter[T]) Count(item T) int {
+	if e, ok := c.counts[item]; ok {
+		return e.count
+	}
+	return 0
+}
+
+// Total returns the number of occurrences of all the items.
+func (c *Counter[T]) Total() int {
+	return c.total
+}
+
+// Len returns the number of distinct items.
+func (c *Counter[T]) Len() int {
+	return len(c.counts)
+}
+
+// MostCommon returns the n items with the highest counts, in descending order of count,
+// or all the items if n is not positive. Items with the same count are returned in first insertion order.
+func (c *Counter[T]) MostCommon(n int) []Pair[T, int] {
+	type ranked struct {
+		item T
+		*counterEntry
+	}
+
+	var all = make([]ranked, 0, len(c.counts))
+	for item, e := range c.counts {
+		all = append(all, ranked{item: item, counterEntry: e})
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].count != all[j].count {
+			return all[i].count > all[j].count
+		}
+		return all[i].seq < all[j].seq
+	})
+
+	if n > 0 && n < len(all) {
+		all = all[:n]
+	}
+
+	var result = make([]Pair[T, int], len(all))
+	for i, r := range all {
+		result[i] = NewPair(r.item, r.count)
+	}
+	return result
+}
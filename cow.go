@@ -0,0 +1,174 @@
+package devtoolkit
+
+import (
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// COWValue is a copy-on-write holder of a value, suitable for read-mostly data such as config snapshots:
+// reads are lock-free and always see a complete value, while updates are serialized and publish a new value.
+// Values are shared between readers, so they must be treated as immutable once stored.
+type COWValue[T any] struct {
+	ptr atomic.Pointer[T]
+	mu  sync.Mutex
+}
+
+// NewCOWValue returns a new COWValue holding value.
+func NewCOWValue[T any](value T) *COWValue[T] {
+	var c = &COWValue[T]{}
+	c.ptr.Store(&value)
+	return c
+}
+
+// Load returns the current value, or the zero value if none was stored. Never blocks.
+func (c *COWValue[T]) Load() T {
+	if p := c.ptr.Load(); p != nil {
+		return *p
+	}
+	var zero T
+	return zero
+}
+
+// Store replaces the current value.
+func (c *COWValue[T]) Store(value T) {
+	c.mu.Lock()
+	c.ptr.Store(&value)
+	c.mu.Unlock()
+}
+
+// Update replaces the current value with the one returned by fn, which receives the current value.
+// Updates are serialized, so no update is lost. fn must return a new value instead of modifying the received one.
+func (c *COWValue[T]) Update(fn func(T) T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var value = fn(c.Load())
+	c.ptr.Store(&value)
+}
+
+// COWSlice is a copy-on-write slice: reads are lock-free and each update copies the slice and publishes the copy.
+type COWSlice[T any] struct {
+	v COWValue[[]T]
+}
+
+// NewCOWSlice returns a new COWSlice containing a copy of items.
+func NewCOWSlice[T any](items ...T) *COWSlice[T] {
+	var c = &COWSlice[T]{}
+	c.v.Store(slices.Clone(items))
+	return c
+}
+
+// Load returns the current slice. It is shared with other readers and must not be modified.
+func (c *COWSlice[T]) Load() []T {
+	return c.v.Load()
+}
+
+// Len returns the number of items in the current slice.
+func (c *COWSlice[T]) Len() int {
+	return len(c.v.Load())
+}
+
+// At returns the item at index in the current slice and true, or the zero value and false if index is out of range.
+func (c *COWSlice[T]) At(index int) (T, bool) {
+	var items = c.v.Load()
+	if index < 0 || index >= len(items) {
+		var zero T
+		return zero, false
+	}
+	return items[index], true
+}
+
+// Append publishes a copy of the slice with items appended.
+func (c *COWSlice[T]) Append(items ...T) {
+	c.v.Update(func(current []T) []T {
+		return append(slices.Clip(current), items...)
+	})
+}
+
+// Set publishes a copy of the slice with the item at index replaced by item.
+// Returns false if index is out of range.
+func (c *COWSlice[T]) Set(index int, item T) bool {
+	var ok bool
+	c.v.Update(func(current []T) []T {
+		if index < 0 || index >= len(current) {
+			return current
+		}
+		ok = true
+		var next = slices.Clone(current)
+		next[index] = item
+		return next
+	})
+	return ok
+}
+
+// Update publishes the slice returned by fn, which receives a copy of the current slice it can modify freely.
+func (c *COWSlice[T]) Update(fn func([]T) []T) {
+	c.v.Update(func(current []T) []T {
+		return fn(slices.Clone(current))
+	})
+}
+
+// COWMap is a copy-on-write map: reads are lock-free and each update copies the map and publishes the copy.
+// It fits maps that are read often and updated rarely; for frequent updates use a map guarded by a mutex.
+type COWMap[K comparable, V any] struct {
+	v COWValue[map[K]V]
+}
+
+// NewCOWMap returns a new COWMap containing a copy of m.
+func NewCOWMap[K comparable, V any](m map[K]V) *COWMap[K, V] {
+	var c = &COWMap[K, V]{}
+	var clone = maps.Clone(m)
+	if clone == nil {
+		clone = make(map[K]V)
+	}
+	c.v.Store(clone)
+	return c
+}
+
+// Load returns the current map. It is shared with other readers and must not be modified.
+func (c *COWMap[K, V]) Load() map[K]V {
+	return c.v.Load()
+}
+
+// Get returns the value for key in the current map and true, or the zero value and false if key is not present.
+func (c *COWMap[K, V]) Get(key K) (V, bool) {
+	v, ok := c.v.Load()[key]
+	return v, ok
+}
+
+// Len returns the number of entries in the current map.
+func (c *COWMap[K, V]) Len() int {
+	return len(c.v.Load())
+}
+
+// Set publishes a copy of the map with key set to value.
+func (c *COWMap[K, V]) Set(key K, value V) {
+	c.Update(func(m map[K]V) {
+		m[key] = value
+	})
+}
+
+// Delete publishes a copy of the map without key. Returns false, publishing nothing, if key was not present.
+func (c *COWMap[K, V]) Delete(key K) bool {
+	var ok bool
+	c.v.Update(func(current map[K]V) map[K]V {
+		if _, ok = current[key]; !ok {
+			return current
+		}
+		var next = maps.Clone(current)
+		delete(next, key)
+		return next
+	})
+	return ok
+}
+
+// Update publishes a copy of the current map after being modified by fn.
+func (c *COWMap[K, V]) Update(fn func(map[K]V)) {
+	c.v.Update(func(current map[K]V) map[K]V {
+		var next = make(map[K]V, len(current))
+		maps.Copy(next, current)
+		fn(next)
+		return next
+	})
+}
@@ -0,0 +1,141 @@
+package devtoolkit
+
+import (
+	"errors"
+	"reflect"
+)
+
+// ErrNilMergeDestination is returned by DeepMerge when the destination is nil.
+var ErrNilMergeDestination = errors.New("nil merge destination")
+
+// SliceMergePolicy defines how DeepMerge merges a non-empty source slice into a destination slice.
+type SliceMergePolicy int
+
+const (
+	SliceMergeReplace SliceMergePolicy = iota // the source slice replaces the destination slice
+	SliceMergeAppend                          // the source items are appended to the destination items
+)
+
+// MapMergePolicy defines how DeepMerge merges a non-empty source map into a destination map.
+type MapMergePolicy int
+
+const (
+	MapMergeUnion   MapMergePolicy = iota // the entries are merged key by key, recursively for keys present in both maps
+	MapMergeReplace                       // the source map replaces the destination map
+)
+
+// MergeOptions holds the policies used by DeepMerge.
+type MergeOptions struct {
+	// OverwriteWithZero indicates whether zero values of the source overwrite the destination values.
+	// Default is false, so only the values set in the source are merged.
+	OverwriteWithZero bool
+
+	// Slices indicates how slices are merged. Default is SliceMergeReplace.
+	Slices SliceMergePolicy
+
+	// Maps indicates how maps are merged. Default is MapMergeUnion.
+	Maps MapMergePolicy
+}
+
+// DeepMerge merges src into dst, walking structs, pointers, maps and interfaces recursively.
+// Scalars and values of other kinds in src overwrite the ones in dst unless they are zero (see MergeOptions.OverwriteWithZero);
+// slices and maps are merged according to the given policies. Unexported struct fields are ignored, and structs without
+// exported fields (e.g. time.Time) are handled as scalars. Values taken from src are not copied, so they may be shared.
+// The maps and pointees of dst are copied before being merged into, so dst may share them with other values, as in
+// 'cfg := defaults; DeepMerge(&cfg, user, opts)', without modifying them.
+// Returns ErrNilMergeDestination if dst is nil.
+func DeepMerge[T any](dst *T, src T, opts MergeOptions) error {
+	if dst == nil {
+		return ErrNilMergeDestination
+	}
+	mergeValue(reflect.ValueOf(dst).Elem(), reflect.ValueOf(&src).Elem(), opts)
+	return nil
+}
+
+// mergeValue merges src into the settable value dst, both of the same type.
+func mergeValue(dst, src reflect.Value, opts MergeOptions) {
+	if src.IsZero() && !opts.OverwriteWithZero {
+		return
+	}
+
+	switch src.Kind() {
+	case reflect.Struct:
+		if !hasExportedFields(src.Type()) {
+			dst.Set(src)
+			return
+		}
+		for i := 0; i < src.NumField(); i++ {
+			if src.Type().Field(i).IsExported() {
+				mergeValue(dst.Field(i), src.Field(i), opts)
+			}
+		}
+
+	case reflect.Pointer:
+		if src.IsNil() {
+			dst.Set(src)
+			return
+		}
+		// merge into a copy of the pointee, which may be shared, e.g. by a defaults value copied into dst
+		var merged = reflect.New(src.Type().Elem())
+		if !dst.IsNil() {
+			merged.Elem().Set(dst.Elem())
+		}
+		mergeValue(merged.Elem(), src.Elem(), opts)
+		dst.Set(merged)
+
+	case reflect.Interface:
+		if src.IsNil() || dst.IsNil() || src.Elem().Type() != dst.Elem().Type() {
+			dst.Set(src)
+			return
+		}
+		var merged = reflect.New(src.Elem().Type()).Elem()
+		merged.Set(dst.Elem())
+		mergeValue(merged, src.Elem(), opts)
+		dst.Set(merged)
+
+	case reflect.Map:
+		if src.IsNil() || opts.Maps == MapMergeReplace {
+			dst.Set(src)
+			return
+		}
+		// merge into a copy of the map, which may be shared, e.g. by a defaults value copied into dst
+		var result = reflect.MakeMapWithSize(src.Type(), dst.Len()+src.Len())
+		for iter := dst.MapRange(); iter.Next(); {
+			result.SetMapIndex(iter.Key(), iter.Value())
+		}
+		for iter := src.MapRange(); iter.Next(); {
+			var existing = result.MapIndex(iter.Key())
+			if !existing.IsValid() {
+				result.SetMapIndex(iter.Key(), iter.Value())
+				continue
+			}
+			var merged = reflect.New(src.Type().Elem()).Elem()
+			merged.Set(existing)
+			mergeValue(merged, iter.Value(), opts)
+			result.SetMapIndex(iter.Key(), merged)
+		}
+		dst.Set(result)
+
+	case reflect.Slice:
+		if src.IsNil() || opts.Slices == SliceMergeReplace {
+			dst.Set(src)
+			return
+		}
+		var merged = reflect.MakeSlice(src.Type(), 0, dst.Len()+src.Len())
+		merged = reflect.AppendSlice(merged, dst)
+		dst.Set(reflect.AppendSlice(merged, src))
+
+	default:
+		dst.Set(src)
+	}
+}
+
+// hasExportedFields returns true if the struct type t has at least one exported field.
+func hasExportedFields(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
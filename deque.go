@@ -0,0 +1,120 @@
+package devtoolkit
+
+// Deque is a generic double-ended queue backed by a growable ring buffer,
+// with O(1) amortized pushes and pops at both ends.
+// The zero value is an empty deque ready to use. A Deque is not safe for concurrent use.
+type Deque[T any] struct {
+	items []T
+	head  int
+	size  int
+}
+
+// NewDeque returns a new Deque with room for 'capacity' items before growing.
+func NewDeque[T any](capacity int) *Deque[T] {
+	return &Deque[T]{items: make([]T, max(capacity, 0))}
+}
+
+// PushBack adds item to the back of the deque.
+func (d *Deque[T]) PushBack(item T) {
+	if d.size == len(d.items) {
+		d.grow()
+	}
+	d.items[d.index(d.size)] = item
+	d.size++
+}
+
+// PushFront adds item to the front of the deque.
+func (d *Deque[T]) PushFront(item T) {
+	if d.size == len(d.items) {
+		d.grow()
+	}
+	d.head = (d.head - 1 + len(d.items)) % len(d.items)
+	d.items[d.head] = item
+	d.size++
+}
+
+// PopFront removes and returns the item at the front of the deque.
+// Returns false if the deque is empty.
+func (d *Deque[T]) PopFront() (T, bool) {
+	var zero T
+	if d.size == 0 {
+		return zero, false
+	}
+
+	item := d.items[d.head]
+	d.items[d.head] = zero
+	d.head = (d.head + 1) % len(d.items)
+	d.size--
+	return item, true
+}
+
+// PopBack removes and returns the item at the back of the deque.
+// Returns false if the deque is empty.
+func (d *Deque[T]) PopBack() (T, bool) {
+	var zero T
+	if d.size == 0 {
+		return zero, false
+	}
+
+	i := d.index(d.size - 1)
+	item := d.items[i]
+	d.items[i] = zero
+	d.size--
+	return item, true
+}
+
+// PeekFront returns the item at the front of the deque without removing it.
+// Returns false if the deque is empty.
+func (d *Deque[T]) PeekFront() (T, bool) {
+	return d.At(0)
+}
+
+// PeekBack returns the item at the back of the deque without removing it.
+// Returns false if the deque is empty.
+func (d *Deque[T]) PeekBack() (T, bool) {
+	return d.At(d.size - 1)
+}
+
+// At returns the item at position i, counting from the front of the deque.
+// Returns false if i is out of range.
+func (d *Deque[T]) At(i int) (T, bool) {
+	if i < 0 || i >= d.size {
+		var zero T
+		return zero, false
+	}
+	return d.items[d.index(i)], true
+}
+
+// Len returns the number of items in the deque.
+func (d *Deque[T]) Len() int {
+	return d.size
+}
+
+// IsEmpty returns true if the deque has no items.
+func (d *Deque[T]) IsEmpty() bool {
+	return d.size == 0
+}
+
+// ToSlice returns the items of the deque from front to back.
+func (d *Deque[T]) ToSlice() []T {
+	var items = make([]T, d.size)
+	for i := range items {
+		items[i] = d.items[d.index(i)]
+	}
+	return items
+}
+
+// index returns the buffer index of the item at position i, counting from the front.
+func (d *Deque[T]) index(i int) int {
+	return (d.head + i) % len(d.items)
+}
+
+// grow doubles the ring buffer, moving the items to the beginning of the new buffer.
+func (d *Deque[T]) grow() {
+	var items = make([]T, max(2*len(d.items), 8))
+	for i := 0; i < d.size; i++ {
+		items[i] = d.items[d.index(i)]
+	}
+	d.items = items
+	d.head = 0
+}
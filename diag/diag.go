@@ -0,0 +1,81 @@
+// Package diag provides process-level diagnostics helpers: goroutine dumps, memory statistics snapshots,
+// CPU profiling for a duration and an optional HTTP handler exposing them, to diagnose production services.
+package diag
+
+import (
+	"errors"
+	"io"
+	"runtime"
+	"runtime/pprof"
+	"time"
+)
+
+// ErrCPUProfileRunning is returned by StartCPUProfileFor when a CPU profile is already being recorded.
+var ErrCPUProfileRunning = errors.New("cpu profile already running")
+
+// MemStats is a snapshot of the main memory and scheduler statistics of the process.
+type MemStats struct {
+	Time          time.Time     `json:"time"`            // when the snapshot was taken
+	Goroutines    int           `json:"goroutines"`      // number of goroutines
+	Alloc         uint64        `json:"alloc"`           // bytes of allocated heap objects
+	TotalAlloc    uint64        `json:"total_alloc"`     // cumulative bytes allocated for heap objects
+	Sys           uint64        `json:"sys"`             // bytes of memory obtained from the OS
+	HeapInuse     uint64        `json:"heap_inuse"`      // bytes in in-use heap spans
+	HeapObjects   uint64        `json:"heap_objects"`    // number of allocated heap objects
+	Mallocs       uint64        `json:"mallocs"`         // cumulative count of heap objects allocated
+	Frees         uint64        `json:"frees"`           // cumulative count of heap objects freed
+	NumGC         uint32        `json:"num_gc"`          // number of completed GC cycles
+	PauseTotal    time.Duration `json:"pause_total"`     // cumulative GC stop-the-world pause time
+	LastGC        time.Time     `json:"last_gc"`         // when the last GC finished, zero if none
+	GCCPUFraction float64       `json:"gc_cpu_fraction"` // fraction of the available CPU time used by the GC
+}
+
+// MemStatsSnapshot returns the current memory statistics of the process.
+// It briefly stops the world, so avoid calling it in tight loops.
+func MemStatsSnapshot() MemStats {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+
+	var lastGC time.Time
+	if ms.LastGC > 0 {
+		lastGC = time.Unix(0, int64(ms.LastGC))
+	}
+
+	return MemStats{
+		Time:          time.Now(),
+		Goroutines:    runtime.NumGoroutine(),
+		Alloc:         ms.Alloc,
+		TotalAlloc:    ms.TotalAlloc,
+		Sys:           ms.Sys,
+		HeapInuse:     ms.HeapInuse,
+		HeapObjects:   ms.HeapObjects,
+		Mallocs:       ms.Mallocs,
+		Frees:         ms.Frees,
+		NumGC:         ms.NumGC,
+		PauseTotal:    time.Duration(ms.PauseTotalNs),
+		LastGC:        lastGC,
+		GCCPUFraction: ms.GCCPUFraction,
+	}
+}
+
+// DumpGoroutines writes the stack traces of all the goroutines to w, in the same format as an unrecovered panic.
+func DumpGoroutines(w io.Writer) error {
+	return pprof.Lookup("goroutine").WriteTo(w, 2)
+}
+
+// StartCPUProfileFor starts recording a CPU profile into w and stops it after d, in the background.
+// The returned channel is closed when the profile is complete and written.
+// Returns ErrCPUProfileRunning if a CPU profile is already being recorded.
+func StartCPUProfileFor(w io.Writer, d time.Duration) (<-chan struct{}, error) {
+	if err := pprof.StartCPUProfile(w); err != nil {
+		// the only failure of StartCPUProfile is a profile already running.
+		return nil, ErrCPUProfileRunning
+	}
+
+	var done = make(chan struct{})
+	time.AfterFunc(d, func() {
+		pprof.StopCPUProfile()
+		close(done)
+	})
+	return done, nil
+}
@@ -0,0 +1,89 @@
+package diag
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/pprof"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultCPUProfileDuration = 30 * time.Second
+	maxCPUProfileDuration     = 5 * time.Minute
+)
+
+// Handler returns an HTTP handler exposing the diagnostics of the process:
+//
+//	GET /goroutines         stack traces of all the goroutines (text)
+//	GET /memstats           memory statistics snapshot (JSON)
+//	GET /pprof/profile      CPU profile, for ?seconds=N (30 by default, pprof format)
+//	GET /pprof/{profile}    named runtime profile, e.g. heap, allocs, goroutine, block, mutex (pprof format, or text with ?debug=1)
+//
+// Unlike importing net/http/pprof, nothing is registered in http.DefaultServeMux. Mount it under a prefix, e.g.
+// mux.Handle("/debug/", http.StripPrefix("/debug", diag.Handler())), and keep it out of public listeners.
+func Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /goroutines", handleGoroutines)
+	mux.HandleFunc("GET /memstats", handleMemStats)
+	mux.HandleFunc("GET /pprof/profile", handleCPUProfile)
+	mux.HandleFunc("GET /pprof/{profile}", handleProfile)
+	return mux
+}
+
+func handleGoroutines(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_ = DumpGoroutines(w)
+}
+
+func handleMemStats(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(MemStatsSnapshot())
+}
+
+func handleCPUProfile(w http.ResponseWriter, r *http.Request) {
+	var d = defaultCPUProfileDuration
+	if s := r.URL.Query().Get("seconds"); s != "" {
+		seconds, err := strconv.Atoi(s)
+		if err != nil || seconds <= 0 {
+			http.Error(w, fmt.Sprintf("invalid seconds '%s'", s), http.StatusBadRequest)
+			return
+		}
+		d = min(time.Duration(seconds)*time.Second, maxCPUProfileDuration)
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", `attachment; filename="profile"`)
+	done, err := StartCPUProfileFor(w, d)
+	if errors.Is(err, ErrCPUProfileRunning) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	select {
+	case <-done:
+	case <-r.Context().Done():
+		// the profile stops at the end of the duration anyway; wait so w is not used after returning.
+		<-done
+	}
+}
+
+func handleProfile(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("profile")
+	profile := pprof.Lookup(name)
+	if profile == nil {
+		http.Error(w, fmt.Sprintf("unknown profile '%s'", name), http.StatusNotFound)
+		return
+	}
+
+	debug, _ := strconv.Atoi(r.URL.Query().Get("debug"))
+	if debug > 0 {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	} else {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, name))
+	}
+	_ = profile.WriteTo(w, debug)
+}
@@ -0,0 +1,154 @@
+package devtoolkit
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const defaultDotEnvFile = ".env"
+
+// ErrInvalidDotEnvLine is returned when a line of a .env file cannot be parsed.
+var ErrInvalidDotEnvLine = errors.New("invalid .env line")
+
+// LoadDotEnv parses the given .env files ('.env' if none) and sets their variables in the process environment.
+// Variables already set in the environment are not overridden, so real environment values win over local files.
+// When several files define the same variable, the last one wins.
+func LoadDotEnv(paths ...string) error {
+	return loadDotEnv(false, paths)
+}
+
+// LoadDotEnvOverride is like LoadDotEnv but overrides the variables already set in the process environment.
+func LoadDotEnvOverride(paths ...string) error {
+	return loadDotEnv(true, paths)
+}
+
+// ReadDotEnv parses the given .env files ('.env' if none) and returns their variables,
+// without modifying the process environment. When several files define the same variable, the last one wins.
+//
+// Supported syntax: 'KEY=VALUE' lines with an optional 'export ' prefix, '#' comments, single-quoted literal values,
+// double-quoted values with '\n', '\t', '\"' and '\\' escapes, and ${VAR} / $VAR references, expanded in unquoted
+// and double-quoted values with the variables defined before or the process environment.
+func ReadDotEnv(paths ...string) (map[string]string, error) {
+	if len(paths) == 0 {
+		paths = []string{defaultDotEnvFile}
+	}
+
+	var vars = make(map[string]string)
+	for _, path := range paths {
+		if err := readDotEnvFile(path, vars); err != nil {
+			return nil, err
+		}
+	}
+	return vars, nil
+}
+
+func loadDotEnv(override bool, paths []string) error {
+	vars, err := ReadDotEnv(paths...)
+	if err != nil {
+		return err
+	}
+
+	for k, v := range vars {
+		if _, exists := os.LookupEnv(k); exists && !override {
+			continue
+		}
+		if err := os.Setenv(k, v); err != nil {
+			return fmt.Errorf("error setting env var '%s': %w", k, err)
+		}
+	}
+	return nil
+}
+
+func readDotEnvFile(path string, vars map[string]string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("error opening .env file '%s': %w", path, err)
+	}
+	defer file.Close()
+
+	var lookup = func(key string) string {
+		if v, ok := vars[key]; ok {
+			return v
+		}
+		return os.Getenv(key)
+	}
+
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, err := parseDotEnvLine(line, lookup)
+		if err != nil {
+			return fmt.Errorf("%w: '%s' line %d: %v", ErrInvalidDotEnvLine, path, lineNumber, err)
+		}
+		vars[key] = value
+	}
+	return scanner.Err()
+}
+
+func parseDotEnvLine(line string, lookup func(string) string) (string, string, error) {
+	line = strings.TrimPrefix(line, "export ")
+	key, value, ok := strings.Cut(line, "=")
+	if !ok {
+		return "", "", errors.New("missing '='")
+	}
+
+	key = strings.TrimSpace(key)
+	if key == "" || strings.ContainsAny(key, " \t") {
+		return "", "", fmt.Errorf("invalid key '%s'", key)
+	}
+
+	value = strings.TrimSpace(value)
+	switch {
+	case strings.HasPrefix(value, "'"):
+		end := strings.Index(value[1:], "'")
+		if end < 0 {
+			return "", "", errors.New("unterminated single-quoted value")
+		}
+		return key, value[1 : end+1], nil
+	case strings.HasPrefix(value, `"`):
+		unquoted, err := unquoteDotEnvValue(value[1:])
+		if err != nil {
+			return "", "", err
+		}
+		return key, os.Expand(unquoted, lookup), nil
+	default:
+		if i := strings.Index(value, " #"); i >= 0 {
+			value = strings.TrimSpace(value[:i])
+		}
+		return key, os.Expand(value, lookup), nil
+	}
+}
+
+// unquoteDotEnvValue returns the contents of a double-quoted value up to the closing quote, resolving escapes.
+func unquoteDotEnvValue(value string) (string, error) {
+	var sb strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c == '"':
+			return sb.String(), nil
+		case c == '\\' && i+1 < len(value):
+			i++
+			switch value[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			case 'r':
+				sb.WriteByte('\r')
+			default:
+				sb.WriteByte(value[i])
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return "", errors.New("unterminated double-quoted value")
+}
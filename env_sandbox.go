@@ -0,0 +1,40 @@
+package devtoolkit
+
+import (
+	"errors"
+	"os"
+	"strings"
+)
+
+// EnvSnapshotter returns a Snapshotter that captures the process environment variables and restores them,
+// unsetting the variables added after the snapshot was taken.
+func EnvSnapshotter() Snapshotter {
+	return SnapshotterFn(func() (Snapshot, error) {
+		var captured = envMap()
+		return RestoreFn(func() error {
+			var errs []error
+			for k := range envMap() {
+				if _, ok := captured[k]; !ok {
+					errs = append(errs, os.Unsetenv(k))
+				}
+			}
+			for k, v := range captured {
+				if current, ok := os.LookupEnv(k); !ok || current != v {
+					errs = append(errs, os.Setenv(k, v))
+				}
+			}
+			return errors.Join(errs...)
+		}), nil
+	})
+}
+
+// envMap returns the process environment variables as a map.
+func envMap() map[string]string {
+	var env = make(map[string]string)
+	for _, kv := range os.Environ() {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			env[k] = v
+		}
+	}
+	return env
+}
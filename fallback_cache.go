@@ -0,0 +1,157 @@
+package devtoolkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrNoFallbackValue is returned by FallbackCache.GetFresh when the computation fails or times out
+// and there is no cached value recent enough to fall back to.
+var ErrNoFallbackValue = errors.New("no fallback value available")
+
+// FallbackCacheOptions holds the options of a FallbackCache.
+type FallbackCacheOptions struct {
+	Timeout time.Duration                                  // indicates the maximum time to wait for a computation. Zero means no timeout.
+	OnStale func(key string, age time.Duration, err error) // indicates a hook called each time a stale value is served, e.g. to record metrics. Default is nil.
+}
+
+// Fresh is a value returned by FallbackCache.GetFresh.
+type Fresh[T any] struct {
+	Value T             // the computed or cached value
+	Stale bool          // true if the computation failed or timed out and the cached value was served
+	Age   time.Duration // time since Value was computed, zero if it was just computed
+	Err   error         // the error of the computation when Stale is true
+}
+
+// FallbackCache implements graceful degradation: it serves freshly computed values, keeping the last one of each key,
+// and falls back to it when a computation fails or takes longer than the timeout.
+// Computations are detached from the cancellation of the caller context: those that time out, or whose caller
+// gives up, keep running in the background and update the cache when they succeed.
+// A FallbackCache is safe for concurrent use.
+type FallbackCache[T any] struct {
+	options FallbackCacheOptions
+	entries map[string]fallbackEntry[T]
+	mu      sync.RWMutex
+}
+
+type fallbackEntry[T any] struct {
+	value      T
+	computedAt time.Time
+}
+
+type fallbackResult[T any] struct {
+	value T
+	err   error
+}
+
+// NewFallbackCache returns a new empty FallbackCache with the provided options or defaults.
+func NewFallbackCache[T any](options *FallbackCacheOptions) *FallbackCache[T] {
+	if options == nil {
+		options = &FallbackCacheOptions{}
+	}
+	return &FallbackCache[T]{
+		options: *options,
+		entries: make(map[string]fallbackEntry[T]),
+	}
+}
+
+// NewFallbackCacheWithOptions is like NewFallbackCache, with the defaults modified by the given option functions,
+// e.g. NewFallbackCacheWithOptions[Rates](WithFallbackTimeout(time.Second)).
+func NewFallbackCacheWithOptions[T any](optFns ...func(*FallbackCacheOptions)) *FallbackCache[T] {
+	var options = &FallbackCacheOptions{}
+	for _, fn := range optFns {
+		fn(options)
+	}
+	return NewFallbackCache[T](options)
+}
+
+// WithFallbackTimeout sets FallbackCacheOptions.Timeout.
+func WithFallbackTimeout(timeout time.Duration) func(*FallbackCacheOptions) {
+	return func(o *FallbackCacheOptions) {
+		o.Timeout = timeout
+	}
+}
+
+// WithOnStale sets FallbackCacheOptions.OnStale.
+func WithOnStale(onStale func(key string, age time.Duration, err error)) func(*FallbackCacheOptions) {
+	return func(o *FallbackCacheOptions) {
+		o.OnStale = onStale
+	}
+}
+
+// GetFresh computes the value of key with compute and caches it.
+// If compute fails or does not finish within the timeout, the cached value of key is returned as stale,
+// provided it was computed less than maxStale ago (any age if maxStale is zero).
+// Returns an error wrapping ErrNoFallbackValue and the computation error otherwise.
+func (fc *FallbackCache[T]) GetFresh(ctx context.Context, key string, maxStale time.Duration, compute func(ctx context.Context) (T, error)) (Fresh[T], error) {
+	var computeCtx = context.WithoutCancel(ctx)
+	var cancel context.CancelFunc = func() {}
+	if fc.options.Timeout > 0 {
+		computeCtx, cancel = context.WithTimeout(computeCtx, fc.options.Timeout)
+	}
+
+	var ch = make(chan fallbackResult[T], 1)
+	go func() {
+		defer cancel()
+		value, err := compute(computeCtx)
+		if err == nil {
+			fc.store(key, value)
+		}
+		ch <- fallbackResult[T]{value: value, err: err}
+	}()
+
+	var err error
+	select {
+	case res := <-ch:
+		if res.err == nil {
+			return Fresh[T]{Value: res.value}, nil
+		}
+		err = res.err
+	case <-computeCtx.Done():
+		err = computeCtx.Err()
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	return fc.fallback(key, maxStale, err)
+}
+
+// Get returns the cached value of key, its age and true, or false if there is no cached value.
+func (fc *FallbackCache[T]) Get(key string) (T, time.Duration, bool) {
+	fc.mu.RLock()
+	defer fc.mu.RUnlock()
+	e, ok := fc.entries[key]
+	if !ok {
+		var zero T
+		return zero, 0, false
+	}
+	return e.value, time.Since(e.computedAt), true
+}
+
+// Delete removes the cached value of key.
+func (fc *FallbackCache[T]) Delete(key string) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	delete(fc.entries, key)
+}
+
+func (fc *FallbackCache[T]) store(key string, value T) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.entries[key] = fallbackEntry[T]{value: value, computedAt: time.Now()}
+}
+
+func (fc *FallbackCache[T]) fallback(key string, maxStale time.Duration, err error) (Fresh[T], error) {
+	value, age, ok := fc.Get(key)
+	if !ok || (maxStale > 0 && age > maxStale) {
+		return Fresh[T]{}, fmt.Errorf("%w for key '%s': %w", ErrNoFallbackValue, key, err)
+	}
+
+	if fc.options.OnStale != nil {
+		fc.options.OnStale(key, age, err)
+	}
+	return Fresh[T]{Value: value, Stale: true, Age: age, Err: err}, nil
+}
@@ -0,0 +1,302 @@
+package devtoolkit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrInvalidFileCacheKey is returned by FileCache when a key contains characters other than letters, digits, '-' and '_'.
+	ErrInvalidFileCacheKey = errors.New("invalid file cache key")
+
+	// ErrFileCacheEntryTooLarge is returned by FileCache.Set when the data is larger than FileCacheOptions.MaxSize.
+	ErrFileCacheEntryTooLarge = errors.New("file cache entry too large")
+)
+
+var fileCacheKeyRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// FileCacheOptions holds the options of a FileCache.
+type FileCacheOptions struct {
+	TTL     time.Duration // indicates how long an entry is valid after being stored. Zero means no expiration.
+	MaxSize int64         // indicates the maximum total size in bytes of the entries. Zero means no limit.
+}
+
+// FileCache is an on-disk cache of byte contents, usually keyed by content hash (see ContentKey),
+// with TTL expiration and size-based eviction of the oldest entries.
+// It can be shared by several processes using the same directory.
+// The total size is tracked in memory, so Set only walks the directory when the limit is exceeded;
+// entries written by other processes are accounted for on the next walk.
+type FileCache struct {
+	dir     string
+	options FileCacheOptions
+	size    int64      // total size of the entries, -1 until the first walk
+	mu      sync.Mutex // guards size and serializes prunes
+}
+
+// NewFileCache returns a new FileCache storing its entries in dir, which is created if it does not exist.
+// If options is nil, entries never expire and the size is not limited.
+func NewFileCache(dir string, options *FileCacheOptions) (*FileCache, error) {
+	if options == nil {
+		options = &FileCacheOptions{}
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("error creating file cache dir '%s': %w", dir, err)
+	}
+	return &FileCache{dir: dir, options: *options, size: -1}, nil
+}
+
+// NewFileCacheWithOptions is like NewFileCache, with the defaults modified by the given option functions,
+// e.g. NewFileCacheWithOptions(dir, WithFileCacheTTL(24*time.Hour)).
+func NewFileCacheWithOptions(dir string, optFns ...func(*FileCacheOptions)) (*FileCache, error) {
+	var options = &FileCacheOptions{}
+	for _, fn := range optFns {
+		fn(options)
+	}
+	return NewFileCache(dir, options)
+}
+
+// WithFileCacheTTL sets FileCacheOptions.TTL.
+func WithFileCacheTTL(ttl time.Duration) func(*FileCacheOptions) {
+	return func(o *FileCacheOptions) {
+		o.TTL = ttl
+	}
+}
+
+// WithFileCacheMaxSize sets FileCacheOptions.MaxSize.
+func WithFileCacheMaxSize(maxSize int64) func(*FileCacheOptions) {
+	return func(o *FileCacheOptions) {
+		o.MaxSize = maxSize
+	}
+}
+
+// ContentKey returns the hex-encoded SHA-256 hash of the concatenation of the given contents.
+func ContentKey(contents ...[]byte) string {
+	h := sha256.New()
+	for _, c := range contents {
+		h.Write(c)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Put stores data under its content key, returning the key.
+func (fc *FileCache) Put(data []byte) (string, error) {
+	key := ContentKey(data)
+	return key, fc.Set(key, data)
+}
+
+// Set stores data under key, replacing any previous entry.
+// If the cache has a MaxSize, the oldest entries are evicted until the total size fits, and data larger than
+// MaxSize is not stored, returning ErrFileCacheEntryTooLarge.
+func (fc *FileCache) Set(key string, data []byte) error {
+	path, err := fc.path(key)
+	if err != nil {
+		return err
+	}
+
+	var size = int64(len(data))
+	if fc.options.MaxSize > 0 && size > fc.options.MaxSize {
+		return fmt.Errorf("%w: '%s' has %d bytes, the limit is %d", ErrFileCacheEntryTooLarge, key, size, fc.options.MaxSize)
+	}
+
+	var replacedSize int64
+	if info, err := os.Stat(path); err == nil {
+		replacedSize = info.Size()
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("error creating file cache dir: %w", err)
+	}
+
+	// write to a temporary file and rename it, so readers never see a partial entry.
+	tmp, err := os.CreateTemp(filepath.Dir(path), key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("error creating file cache entry '%s': %w", key, err)
+	}
+	if _, err = tmp.Write(data); err == nil {
+		err = tmp.Close()
+	} else {
+		_ = tmp.Close()
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("error writing file cache entry '%s': %w", key, err)
+	}
+
+	if fc.options.MaxSize <= 0 {
+		return nil
+	}
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	if fc.size >= 0 {
+		fc.size += size - replacedSize
+		if fc.size <= fc.options.MaxSize {
+			return nil
+		}
+	}
+	return fc.prune(path)
+}
+
+// Get returns the data stored under key and true, or nil and false if there is no entry or it expired.
+// Expired entries are removed.
+func (fc *FileCache) Get(key string) ([]byte, bool) {
+	path, err := fc.path(key)
+	if err != nil {
+		return nil, false
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, false
+	}
+	if fc.expired(info, time.Now()) {
+		if os.Remove(path) == nil {
+			fc.removed(info.Size())
+		}
+		return nil, false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// Has returns true if there is a non-expired entry stored under key.
+func (fc *FileCache) Has(key string) bool {
+	path, err := fc.path(key)
+	if err != nil {
+		return false
+	}
+	info, err := os.Stat(path)
+	return err == nil && !fc.expired(info, time.Now())
+}
+
+// Delete removes the entry stored under key, if any.
+func (fc *FileCache) Delete(key string) error {
+	path, err := fc.path(key)
+	if err != nil {
+		return err
+	}
+	info, statErr := os.Stat(path)
+	if err = os.Remove(path); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("error deleting file cache entry '%s': %w", key, err)
+	}
+	if statErr == nil {
+		fc.removed(info.Size())
+	}
+	return nil
+}
+
+// Prune removes the expired entries and, if the cache has a MaxSize, the oldest entries
+// until the total size fits.
+func (fc *FileCache) Prune() error {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return fc.prune("")
+}
+
+// prune implements Prune, evicting the entry at keep last. Must be called with fc.mu held.
+func (fc *FileCache) prune(keep string) error {
+	type entry struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+
+	var now = time.Now()
+	var entries []entry
+	var total int64
+	err := filepath.WalkDir(fc.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) == ".tmp" {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		if fc.expired(info, now) {
+			_ = os.Remove(path)
+			return nil
+		}
+
+		entries = append(entries, entry{path: path, size: info.Size(), modTime: info.ModTime()})
+		total += info.Size()
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error pruning file cache: %w", err)
+	}
+
+	fc.size = total
+	if fc.options.MaxSize <= 0 || total <= fc.options.MaxSize {
+		return nil
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if (entries[i].path == keep) != (entries[j].path == keep) {
+			return entries[j].path == keep
+		}
+		return entries[i].modTime.Before(entries[j].modTime)
+	})
+	for _, e := range entries {
+		if fc.size <= fc.options.MaxSize {
+			break
+		}
+		if err := os.Remove(e.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("error evicting file cache entry: %w", err)
+		}
+		fc.size -= e.size
+	}
+	return nil
+}
+
+// removed accounts for the removal of an entry of the given size.
+func (fc *FileCache) removed(size int64) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	if fc.size >= 0 {
+		fc.size = max(fc.size-size, 0)
+	}
+}
+
+// path returns the path of the entry stored under key, sharded in sub-directories by its first two characters.
+func (fc *FileCache) path(key string) (string, error) {
+	if !fileCacheKeyRegexp.MatchString(key) {
+		return "", fmt.Errorf("%w: '%s'", ErrInvalidFileCacheKey, key)
+	}
+
+	var shard = key
+	if len(shard) > 2 {
+		shard = shard[:2]
+	}
+	return filepath.Join(fc.dir, shard, key), nil
+}
+
+func (fc *FileCache) expired(info fs.FileInfo, now time.Time) bool {
+	return fc.options.TTL > 0 && now.Sub(info.ModTime()) > fc.options.TTL
+}
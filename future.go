@@ -0,0 +1,94 @@
+package devtoolkit
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Future is the placeholder of a value of type T, or an error, that becomes available asynchronously.
+// It is completed once with Complete and read any number of times with Get.
+type Future[T any] struct {
+	value T
+	err   error
+	done  chan struct{}
+	once  sync.Once
+}
+
+// NewFuture returns a new pending Future.
+func NewFuture[T any]() *Future[T] {
+	return &Future[T]{done: make(chan struct{})}
+}
+
+// Async runs fn in a new goroutine and returns a Future completed with its result.
+func Async[T any](fn func() (T, error)) *Future[T] {
+	var f = NewFuture[T]()
+	go func() {
+		f.Complete(fn())
+	}()
+	return f
+}
+
+// Complete sets the value and error of the future and wakes up the callers waiting on Get.
+// Returns false, ignoring value and err, if the future was already completed.
+func (f *Future[T]) Complete(value T, err error) bool {
+	var completed bool
+	f.once.Do(func() {
+		f.value, f.err = value, err
+		close(f.done)
+		completed = true
+	})
+	return completed
+}
+
+// Get blocks until the future is completed and returns its value and error.
+// Returns ctx.Err() if ctx is done first.
+func (f *Future[T]) Get(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Done returns a channel that is closed when the future is completed.
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// IsDone returns true if the future is completed.
+func (f *Future[T]) IsDone() bool {
+	select {
+	case <-f.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Then returns a Future completed with the result of fn applied to the value of f once f is completed.
+// If f completes with an error, fn is not called and the returned future completes with that error.
+func Then[T, R any](f *Future[T], fn func(T) (R, error)) *Future[R] {
+	var next = NewFuture[R]()
+	go func() {
+		<-f.done
+		if f.err != nil {
+			var zero R
+			next.Complete(zero, f.err)
+			return
+		}
+		next.Complete(fn(f.value))
+	}()
+	return next
+}
+
+// FutureFromResponse returns a Future completed when all the functions of a ConcurrentExec execution are done,
+// with their results and the join of their non-nil errors.
+func FutureFromResponse(resp ConcurrentExecResponse) *Future[[]any] {
+	return Async(func() ([]any, error) {
+		var errs = resp.GetNotNilErrors()
+		return resp.Results(), errors.Join(errs...)
+	})
+}
@@ -1,143 +1,71 @@
 package main
 
 import (
-	"bytes"
-	"fmt"
-	"golang.org/x/tools/imports"
-	"path/filepath"
-	"text/template"
+	"flag"
+	"github.com/rendis/devtoolkit/generator/struct-guard/structguard"
+	"log"
+	"os"
 )
 
 func main() {
+	apiReport := flag.String("api-report", "", "path of the API report of the generated wrappers, compared with the previous one and overwritten if compatible")
+	acceptBreaking := flag.Bool("accept-breaking", false, "overwrite the API report even if it has breaking changes")
+	flag.Parse()
 
 	loadGenProp()
 
-	// exclude files to map
-	var excludeFilesMap = make(map[string]bool)
-	for _, file := range generatorProp.ExcludeFilesToScan {
-		file = filepath.Clean(file)
-		excludeFilesMap[file] = true
+	files, err := structguard.Generate(*generatorProp)
+	if err != nil {
+		log.Fatalf("failed to generate code.\n%v", err)
 	}
 
-	// extract to scan
-	var filesToScanMap = make(map[string]map[string]struct{})
-	for _, path := range generatorProp.ToScan {
-		if isDirectory(path) {
-			dir := filepath.Clean(path)
-			files, err := listGoFiles(dir)
-			if err != nil {
-				panic(err)
-			}
-			for _, file := range files {
-				fileName := filepath.Base(file)
-				if excludeFilesMap[file] || filepath.Ext(file) != ".go" || fileName == *generatorProp.GeneratedFileName {
-					continue
-				}
-				if filesToScanMap[dir] == nil {
-					filesToScanMap[dir] = make(map[string]struct{})
-				}
-				filesToScanMap[dir][file] = struct{}{}
-			}
-		} else {
-			file := filepath.Clean(path)
-			fileName := filepath.Base(file)
-			if excludeFilesMap[file] || filepath.Ext(file) != ".go" || fileName == *generatorProp.GeneratedFileName {
-				continue
-			}
-
-			dir := filepath.Dir(file)
-			if filesToScanMap[dir] == nil {
-				filesToScanMap[dir] = make(map[string]struct{})
-			}
-			filesToScanMap[dir][file] = struct{}{}
-		}
-
+	for _, file := range files {
+		removeFile(file.Path)
+		saveFile(file.Path, file.Content)
 	}
 
-	// process files
-	for dir, files := range filesToScanMap {
-		genCodeFile := filepath.Join(dir, *generatorProp.GeneratedFileName)
-		removeFile(genCodeFile)
-
-		filesArr := make([]string, 0, len(files))
-		for file := range files {
-			filesArr = append(filesArr, file)
-		}
-		code := genCode(filesArr)
-		saveFile(genCodeFile, code)
+	if *apiReport != "" && !updateAPIReport(*apiReport, files, *acceptBreaking) {
+		os.Exit(1)
 	}
 }
 
-func genCode(files []string) string {
-	analysis, err := extractStructsFromFilesInSamePackage(files)
+// updateAPIReport writes the API report of files to path, logging the changes from the previous report, if any.
+// If there are breaking changes, the previous report is kept, so later runs keep failing, and false is returned,
+// unless acceptBreaking is set.
+func updateAPIReport(path string, files []structguard.GeneratedFile, acceptBreaking bool) bool {
+	previous, err := structguard.ReadAPIReport(path)
 	if err != nil {
-		panic(err)
+		log.Fatalf("failed to read API report '%s'.\n%v", path, err)
 	}
 
-	var codes string
-
-	for _, structMap := range analysis.structs {
-		for k, v := range structMap {
-			wrapperName := *generatorProp.GeneratedStructPrefix + k + *generatorProp.GeneratedStructPostfix
-
-			if generatorProp.ForceExport {
-				wrapperName = firstToUpper(wrapperName)
-			}
-
-			t := template.Must(template.New(wrapperName).Parse(wrapperStructTemplate))
-			var b bytes.Buffer
-
-			err := t.Execute(&b, struct {
-				TypeName    string
-				WrapperName string
-				Fields      []map[string]string
-			}{
-				TypeName:    k,
-				WrapperName: wrapperName,
-				Fields:      v,
-			})
-
-			if err != nil {
-				panic(err)
-			}
-
-			codes = fmt.Sprintf("%s\n%s", codes, b.String())
-		}
+	current, err := structguard.BuildAPIReport(files)
+	if err != nil {
+		log.Fatalf("failed to build API report.\n%v", err)
 	}
 
-	// generate the header
-	t := template.Must(template.New("header").Parse(wrapperHeaderTemplate))
-	var b bytes.Buffer
-	var fileImports []string
-	for k := range analysis.imports {
-		fileImports = append(fileImports, k)
+	// the first report has nothing to compare with
+	var changes []structguard.APIChange
+	if len(previous.Entries) > 0 {
+		changes = structguard.DiffAPIReports(previous, current)
 	}
-	err = t.Execute(&b, struct {
-		PackageName string
-		Imports     []string
-		Content     string
-	}{
-		PackageName: analysis.packageName,
-		Imports:     fileImports,
-		Content:     codes,
-	})
 
-	if err != nil {
-		panic(err)
+	var compatible = true
+	for _, change := range changes {
+		if change.Breaking() {
+			compatible = false
+			log.Printf("BREAKING: %s", change)
+		} else {
+			log.Print(change)
+		}
 	}
 
-	opt := &imports.Options{
-		Comments:   true,
-		TabIndent:  true,
-		TabWidth:   8,
-		FormatOnly: false,
+	if !compatible && !acceptBreaking {
+		log.Printf("API report '%s' not updated, run with --accept-breaking to accept the breaking changes", path)
+		return false
 	}
 
-	bb, err := imports.Process("", b.Bytes(), opt)
-
-	if err != nil {
-		panic(err)
+	if err := structguard.WriteAPIReport(path, current); err != nil {
+		log.Fatalf("failed to write API report '%s'.\n%v", path, err)
 	}
-
-	return string(bb)
+	return true
 }
@@ -0,0 +1,198 @@
+package structguard
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// APIEntry is an exported function or method of a generated file.
+type APIEntry struct {
+	// Package is the directory of the generated file, with forward slashes.
+	Package string `json:"package"`
+
+	// Receiver is the name of the receiver type, empty for functions.
+	Receiver string `json:"receiver,omitempty"`
+
+	// Name is the name of the function or method.
+	Name string `json:"name"`
+
+	// Signature is the parameters and results, e.g. '(name string) *UserWrapperBuilder'.
+	Signature string `json:"signature"`
+}
+
+// ID returns the identifier of the entry, made of its package, receiver and name.
+func (e APIEntry) ID() string {
+	if e.Receiver == "" {
+		return e.Package + "." + e.Name
+	}
+	return e.Package + "." + e.Receiver + "." + e.Name
+}
+
+// APIReport is the machine-readable list of the exported functions and methods of the generated files.
+type APIReport struct {
+	Entries []APIEntry `json:"entries"`
+}
+
+// APIChangeKind is the kind of change of an APIEntry between two reports.
+type APIChangeKind string
+
+const (
+	APIAdded   APIChangeKind = "added"   // the entry is new
+	APIRemoved APIChangeKind = "removed" // the entry no longer exists, e.g. because a struct field was renamed or removed
+	APIChanged APIChangeKind = "changed" // the entry exists with a different signature
+)
+
+// APIChange is a difference between two API reports.
+type APIChange struct {
+	Kind APIChangeKind `json:"kind"`
+
+	// Old is the entry of the previous report, nil for added entries.
+	Old *APIEntry `json:"old,omitempty"`
+
+	// New is the entry of the current report, nil for removed entries.
+	New *APIEntry `json:"new,omitempty"`
+}
+
+// Breaking returns true if the change can break the code using the generated wrappers.
+func (c APIChange) Breaking() bool {
+	return c.Kind == APIRemoved || c.Kind == APIChanged
+}
+
+// String returns a one-line description of the change.
+func (c APIChange) String() string {
+	switch c.Kind {
+	case APIAdded:
+		return fmt.Sprintf("added %s%s", c.New.ID(), c.New.Signature)
+	case APIRemoved:
+		return fmt.Sprintf("removed %s%s", c.Old.ID(), c.Old.Signature)
+	default:
+		return fmt.Sprintf("changed %s%s -> %s", c.Old.ID(), c.Old.Signature, c.New.Signature)
+	}
+}
+
+// BuildAPIReport parses the generated files and returns the report of their exported functions and methods,
+// sorted by package, receiver and name.
+func BuildAPIReport(files []GeneratedFile) (*APIReport, error) {
+	var report = &APIReport{Entries: []APIEntry{}}
+	for _, file := range files {
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, file.Path, file.Content, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing generated file '%s': %w", file.Path, err)
+		}
+
+		pkg := filepath.ToSlash(filepath.Dir(file.Path))
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || !fn.Name.IsExported() {
+				continue
+			}
+
+			var sig bytes.Buffer
+			if err := printer.Fprint(&sig, fset, fn.Type); err != nil {
+				return nil, err
+			}
+
+			report.Entries = append(report.Entries, APIEntry{
+				Package:   pkg,
+				Receiver:  receiverName(fn),
+				Name:      fn.Name.Name,
+				Signature: strings.TrimPrefix(sig.String(), "func"),
+			})
+		}
+	}
+
+	sort.Slice(report.Entries, func(i, j int) bool {
+		return report.Entries[i].ID() < report.Entries[j].ID()
+	})
+	return report, nil
+}
+
+// ReadAPIReport reads a report written by WriteAPIReport.
+// Returns an empty report if the file does not exist.
+func ReadAPIReport(path string) (*APIReport, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &APIReport{Entries: []APIEntry{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var report APIReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("error parsing API report '%s': %w", path, err)
+	}
+	return &report, nil
+}
+
+// WriteAPIReport writes the report to path as indented JSON.
+func WriteAPIReport(path string, report *APIReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// DiffAPIReports returns the changes from the old report to the new one, sorted by entry ID.
+func DiffAPIReports(old, new *APIReport) []APIChange {
+	var oldEntries = make(map[string]APIEntry, len(old.Entries))
+	for _, e := range old.Entries {
+		oldEntries[e.ID()] = e
+	}
+
+	var changes []APIChange
+	for _, e := range new.Entries {
+		id := e.ID()
+		prev, ok := oldEntries[id]
+		delete(oldEntries, id)
+		switch {
+		case !ok:
+			changes = append(changes, APIChange{Kind: APIAdded, New: &e})
+		case prev.Signature != e.Signature:
+			changes = append(changes, APIChange{Kind: APIChanged, Old: &prev, New: &e})
+		}
+	}
+	for _, e := range oldEntries {
+		changes = append(changes, APIChange{Kind: APIRemoved, Old: &e})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changeID(changes[i]) < changeID(changes[j])
+	})
+	return changes
+}
+
+func changeID(c APIChange) string {
+	if c.New != nil {
+		return c.New.ID()
+	}
+	return c.Old.ID()
+}
+
+// receiverName returns the name of the receiver type of fn, empty if fn is not a method.
+func receiverName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+
+	var expr = fn.Recv.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
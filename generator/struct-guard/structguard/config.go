@@ -0,0 +1,67 @@
+package structguard
+
+import (
+	"github.com/rendis/devtoolkit"
+	"path/filepath"
+)
+
+const defaultGoFile = "codegen.go"
+
+// Config holds the struct-guard generation options.
+// It is also the 'generators.struct-guard' section of the 'devtoolkit.yml' file.
+type Config struct {
+	// GeneratedFileName is the name of the generated file, defaults to 'codegen.go'
+	GeneratedFileName *string `yaml:"generated-file-name"`
+
+	// GeneratedStructPrefix is the prefix to be added to the generated struct name, defaults to ''
+	GeneratedStructPrefix *string `yaml:"generated-struct-prefix"`
+
+	// GeneratedStructPostfix is the postfix to be added to the generated struct name, defaults to 'Wrapper'
+	GeneratedStructPostfix *string `yaml:"generated-struct-postfix"`
+
+	// ToScan is the list of directories or files to scan for structs
+	ToScan []string `yaml:"to-scan"`
+
+	// ExcludeFilesToScan is the list of files to exclude from scanning
+	ExcludeFilesToScan []string `yaml:"exclude-files-to-scan"`
+
+	// ForceExport is a flag to force export of the generated struct, defaults to false (private)
+	ForceExport bool `yaml:"force-export"`
+
+	// ValidateOnBuild is a flag to generate builders whose Build() validates the struct 'validate' tags
+	// and returns an error, defaults to false
+	ValidateOnBuild bool `yaml:"validate-on-build"`
+
+	// OnChangeHooks is a flag to generate an OnChange callback registration on wrappers,
+	// invoked after each tracked mutation, defaults to false
+	OnChangeHooks bool `yaml:"on-change-hooks"`
+
+	// ThreadSafe is a flag to generate wrappers whose methods are guarded by a sync.RWMutex,
+	// including CompareAndSet methods for comparable fields, defaults to false
+	ThreadSafe bool `yaml:"thread-safe"`
+
+	// NumericHelpers is a flag to generate Add and Sub methods for numeric fields, defaults to false
+	NumericHelpers bool `yaml:"numeric-helpers"`
+
+	// CacheDir is the directory of a content-addressed cache of generated files, keyed by the configuration and the
+	// contents of the scanned files, so unchanged packages are not regenerated, defaults to nil (no cache)
+	CacheDir *string `yaml:"cache-dir"`
+}
+
+func (c *Config) SetDefaults() {
+	if c.GeneratedFileName == nil {
+		c.GeneratedFileName = devtoolkit.ToPtr(defaultGoFile)
+	} else {
+		if ext := filepath.Ext(*c.GeneratedFileName); ext != ".go" {
+			c.GeneratedFileName = devtoolkit.ToPtr(*c.GeneratedFileName + ".go")
+		}
+	}
+
+	if c.GeneratedStructPrefix == nil {
+		c.GeneratedStructPrefix = devtoolkit.ToPtr("")
+	}
+
+	if c.GeneratedStructPostfix == nil {
+		c.GeneratedStructPostfix = devtoolkit.ToPtr("Wrapper")
+	}
+}
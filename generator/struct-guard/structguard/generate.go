@@ -0,0 +1,248 @@
+// Package structguard generates wrapper structs for tracking changes to the fields of the original struct.
+// It is the library behind the 'struct-guard' generator and can be used by build tools and tests to run
+// the generation in memory, without executing the binary or touching disk.
+package structguard
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/rendis/devtoolkit"
+	"golang.org/x/tools/imports"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/template"
+)
+
+// GeneratedFile is a generated source file.
+type GeneratedFile struct {
+	// Path is the path where the file is expected to be written, inside the scanned package directory.
+	Path string
+
+	// Content is the formatted Go source code.
+	Content []byte
+}
+
+// Generate scans the files and directories of cfg.ToScan and returns one generated file per package directory,
+// sorted by path, so the output is deterministic. Nothing is written to disk, except the cache entries when cfg.CacheDir is set; cfg is not modified.
+func Generate(cfg Config) ([]GeneratedFile, error) {
+	cfg.SetDefaults()
+
+	filesToScanMap, err := collectFilesToScan(&cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var cache *devtoolkit.FileCache
+	if cfg.CacheDir != nil {
+		if cache, err = devtoolkit.NewFileCache(*cfg.CacheDir, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	var dirs = devtoolkit.MapKeys(filesToScanMap)
+	sort.Strings(dirs)
+
+	var generated = make([]GeneratedFile, 0, len(dirs))
+	for _, dir := range dirs {
+		filesArr := devtoolkit.MapKeys(filesToScanMap[dir])
+		sort.Strings(filesArr)
+
+		code, err := genCodeCached(cache, &cfg, filesArr)
+		if err != nil {
+			return nil, fmt.Errorf("error generating code for '%s': %w", dir, err)
+		}
+
+		generated = append(generated, GeneratedFile{
+			Path:    filepath.Join(dir, *cfg.GeneratedFileName),
+			Content: code,
+		})
+	}
+	return generated, nil
+}
+
+// collectFilesToScan groups the go files to scan by directory.
+func collectFilesToScan(cfg *Config) (map[string]map[string]struct{}, error) {
+	// exclude files to map
+	var excludeFilesMap = make(map[string]bool)
+	for _, file := range cfg.ExcludeFilesToScan {
+		file = filepath.Clean(file)
+		excludeFilesMap[file] = true
+	}
+
+	var filesToScanMap = make(map[string]map[string]struct{})
+	var addFile = func(file string) {
+		fileName := filepath.Base(file)
+		if excludeFilesMap[file] || filepath.Ext(file) != ".go" || fileName == *cfg.GeneratedFileName {
+			return
+		}
+
+		dir := filepath.Dir(file)
+		if filesToScanMap[dir] == nil {
+			filesToScanMap[dir] = make(map[string]struct{})
+		}
+		filesToScanMap[dir][file] = struct{}{}
+	}
+
+	for _, path := range cfg.ToScan {
+		stat, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get file info for '%s': %w", path, err)
+		}
+
+		if !stat.IsDir() {
+			addFile(filepath.Clean(path))
+			continue
+		}
+
+		files, err := listGoFiles(filepath.Clean(path))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			addFile(file)
+		}
+	}
+	return filesToScanMap, nil
+}
+
+// genCodeCached returns the code generated for files from the cache, if present, generating and caching it otherwise.
+// The cache key covers the configuration, the templates and the paths and contents of the files, which must be sorted.
+func genCodeCached(cache *devtoolkit.FileCache, cfg *Config, files []string) ([]byte, error) {
+	if cache == nil {
+		return genCode(cfg, files)
+	}
+
+	cfgArr, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var contents = [][]byte{cfgArr, []byte(wrapperHeaderTemplate), []byte(wrapperStructTemplate)}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		contents = append(contents, []byte(file), content)
+	}
+
+	key := devtoolkit.ContentKey(contents...)
+	if code, ok := cache.Get(key); ok {
+		return code, nil
+	}
+
+	code, err := genCode(cfg, files)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cache.Set(key, code); err != nil {
+		return nil, err
+	}
+	return code, nil
+}
+
+func genCode(cfg *Config, files []string) ([]byte, error) {
+	analysis, err := extractStructsFromFilesInSamePackage(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var codes string
+
+	for _, structMap := range analysis.structs {
+		for k, v := range structMap {
+			wrapperName := *cfg.GeneratedStructPrefix + k + *cfg.GeneratedStructPostfix
+
+			if cfg.ForceExport {
+				wrapperName = firstToUpper(wrapperName)
+			}
+
+			t := template.Must(template.New(wrapperName).Parse(wrapperStructTemplate))
+			var b bytes.Buffer
+
+			err := t.Execute(&b, struct {
+				TypeName        string
+				WrapperName     string
+				Fields          []map[string]string
+				ValidateOnBuild bool
+				OnChangeHooks   bool
+				ThreadSafe      bool
+				NumericHelpers  bool
+			}{
+				TypeName:        k,
+				WrapperName:     wrapperName,
+				Fields:          v,
+				ValidateOnBuild: cfg.ValidateOnBuild,
+				OnChangeHooks:   cfg.OnChangeHooks,
+				ThreadSafe:      cfg.ThreadSafe,
+				NumericHelpers:  cfg.NumericHelpers,
+			})
+
+			if err != nil {
+				return nil, err
+			}
+
+			codes = fmt.Sprintf("%s\n%s", codes, b.String())
+		}
+	}
+
+	// generate the header
+	t := template.Must(template.New("header").Parse(wrapperHeaderTemplate))
+	var b bytes.Buffer
+	if cfg.ValidateOnBuild {
+		analysis.imports[validatorImport] = struct{}{}
+	}
+	if cfg.ThreadSafe {
+		analysis.imports[syncImport] = struct{}{}
+	}
+	var fileImports []string
+	for k := range analysis.imports {
+		fileImports = append(fileImports, k)
+	}
+	err = t.Execute(&b, struct {
+		PackageName     string
+		Imports         []string
+		Content         string
+		ValidateOnBuild bool
+	}{
+		PackageName:     analysis.packageName,
+		Imports:         fileImports,
+		Content:         codes,
+		ValidateOnBuild: cfg.ValidateOnBuild,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &imports.Options{
+		Comments:   true,
+		TabIndent:  true,
+		TabWidth:   8,
+		FormatOnly: false,
+	}
+
+	return imports.Process("", b.Bytes(), opt)
+}
+
+func listGoFiles(dirPath string) ([]string, error) {
+	var files []string
+
+	err := filepath.WalkDir(dirPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() && filepath.Ext(d.Name()) == ".go" {
+			files = append(files, path)
+		}
+
+		return nil
+	})
+
+	return files, err
+}
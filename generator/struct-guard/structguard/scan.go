@@ -1,4 +1,4 @@
-package main
+package structguard
 
 import (
 	"fmt"
@@ -21,12 +21,31 @@ type structsAnalysis struct {
 	structs     []map[string][]map[string]string
 }
 
+// comparableBasicTypes are the predeclared types that can safely be compared with '=='.
+var comparableBasicTypes = map[string]bool{
+	"bool": true, "string": true, "byte": true, "rune": true, "uintptr": true,
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"float32": true, "float64": true, "complex64": true, "complex128": true,
+}
+
+// numericBasicTypes are the predeclared numeric types supporting '+' and '-'.
+var numericBasicTypes = map[string]bool{
+	"byte": true, "rune": true, "uintptr": true,
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"float32": true, "float64": true, "complex64": true, "complex128": true,
+}
+
 type fieldTypeInfo struct {
 	fieldTypeStr     string
 	composedTyp      fieldComposedType
 	isArray          bool
 	isMap            bool
 	isPtr            bool
+	isComparable     bool
+	isNumeric        bool
+	isTime           bool
 	ptrFieldTypeStr  string
 	composedTypDesc1 string
 	composedTypDesc2 string
@@ -122,6 +141,9 @@ func processGenDecl(genDecl *ast.GenDecl, imports map[string]struct{}, structs m
 					"IsArray":             fmt.Sprintf("%t", fieldInfo.isArray),
 					"IsMap":               fmt.Sprintf("%t", fieldInfo.isMap),
 					"IsPtr":               fmt.Sprintf("%t", fieldInfo.isPtr),
+					"IsComparable":        fmt.Sprintf("%t", fieldInfo.isComparable),
+					"IsNumeric":           fmt.Sprintf("%t", fieldInfo.isNumeric),
+					"IsTime":              fmt.Sprintf("%t", fieldInfo.isTime),
 					"PtrFieldType":        fieldInfo.ptrFieldTypeStr,
 					"ComposedTypeDesc1":   fieldInfo.composedTypDesc1,
 					"ComposedTypeDesc2":   fieldInfo.composedTypDesc2,
@@ -139,6 +161,8 @@ func getFieldTypeFromExpr(expr ast.Expr) *fieldTypeInfo {
 		return &fieldTypeInfo{
 			fieldTypeStr: expr.(*ast.Ident).Name,
 			composedTyp:  fieldComposedTypeNotComposed,
+			isComparable: comparableBasicTypes[expr.(*ast.Ident).Name],
+			isNumeric:    numericBasicTypes[expr.(*ast.Ident).Name],
 		}
 	case *ast.StarExpr:
 		typeInfo := getFieldTypeFromExpr(expr.(*ast.StarExpr).X)
@@ -147,6 +171,7 @@ func getFieldTypeFromExpr(expr ast.Expr) *fieldTypeInfo {
 			ptrFieldTypeStr: typeInfo.fieldTypeStr,
 			composedTyp:     fieldComposedTypeNotComposed,
 			isPtr:           true,
+			isComparable:    true,
 		}
 	case *ast.SelectorExpr:
 		se := expr.(*ast.SelectorExpr)
@@ -154,6 +179,7 @@ func getFieldTypeFromExpr(expr ast.Expr) *fieldTypeInfo {
 		return &fieldTypeInfo{
 			fieldTypeStr: typ,
 			composedTyp:  fieldComposedTypeNotComposed,
+			isTime:       typ == "time.Time",
 		}
 	case *ast.ArrayType:
 		at := expr.(*ast.ArrayType)
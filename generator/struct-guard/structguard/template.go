@@ -1,4 +1,9 @@
-package main
+package structguard
+
+const (
+	validatorImport = `"github.com/go-playground/validator/v10"`
+	syncImport      = `"sync"`
+)
 
 const wrapperHeaderTemplate = `// Code generated by 'devtoolkit/generators/struct-guard'. DO NOT EDIT.
 // Any changes made to this file will be lost when the file is regenerated
@@ -9,16 +14,42 @@ package {{.PackageName}}
 import {{.}}
 {{- end }}
 
+{{- if .ValidateOnBuild }}
+
+// structGuardValidator validates the structs built by the generated builders
+var structGuardValidator = validator.New(validator.WithRequiredStructEnabled())
+{{- end }}
+
 {{- .Content }}
 `
 
 const wrapperStructTemplate = `
+{{- define "lock" }}{{ if .ThreadSafe }}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+{{- end }}{{ end }}
+{{- define "mlock" }}{{ if .ThreadSafe }}
+	w.mu.Lock()
+{{- end }}{{ end }}
+{{- define "unlock" }}{{ if .ThreadSafe }}
+	w.mu.Unlock()
+{{- end }}{{ end }}
+{{- define "rlock" }}{{ if .ThreadSafe }}
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+{{- end }}{{ end }}
 {{- $typeName := .TypeName }}
 {{- $wrapperName := .WrapperName }}
 // {{$wrapperName}} wraps {{$typeName}} with changes tracking
 type {{$wrapperName}} struct {
     {{$typeName}}
     changes {{$typeName}}Changes
+    {{- if .OnChangeHooks }}
+    onChangeHooks []func(field string, oldValue, newValue any)
+    {{- end }}
+    {{- if .ThreadSafe }}
+    mu sync.RWMutex
+    {{- end }}
 }
 
 // {{$typeName}}Changes is a struct to track changes in {{$typeName}}
@@ -30,29 +61,131 @@ type {{$typeName}}Changes struct {
 
 // ResetChanges resets the changes in {{$typeName}}
 func (w *{{$wrapperName}}) ResetChanges() {
+	{{- template "lock" $ }}
 	w.changes = {{$typeName}}Changes{}
 }
 
+{{- if .OnChangeHooks }}
+// OnChange registers a callback invoked after each tracked mutation of {{$typeName}}.
+// For map fields, oldValue and newValue are the entry values (nil if the entry did not exist or was removed)
+func (w *{{$wrapperName}}) OnChange(fn func(field string, oldValue, newValue any)) {
+	{{- template "lock" $ }}
+	w.onChangeHooks = append(w.onChangeHooks, fn)
+}
+
+// notifyChange invokes the registered OnChange callbacks
+// It must be called without holding the lock, so callbacks can call the wrapper
+func (w *{{$wrapperName}}) notifyChange(field string, oldValue, newValue any) {
+	{{- if .ThreadSafe }}
+	w.mu.RLock()
+	hooks := w.onChangeHooks
+	w.mu.RUnlock()
+	{{- else }}
+	hooks := w.onChangeHooks
+	{{- end }}
+	for _, fn := range hooks {
+		fn(field, oldValue, newValue)
+	}
+}
+{{- end }}
+
 {{- range .Fields }}
 // Get{{.FieldNameUpperCamel}} returns the value of {{$typeName}}.{{.OriginalName}}
 func (w *{{$wrapperName}}) Get{{.FieldNameUpperCamel}}() {{.FieldType}} {
+	{{- template "rlock" $ }}
     return w.{{$typeName}}.{{.OriginalName}}
 }
 
 // Get{{.FieldNameUpperCamel}}WithChange returns the value of {{$typeName}}.{{.OriginalName}} and a boolean indicating if the value has changed
 func (w *{{$wrapperName}}) Get{{.FieldNameUpperCamel}}WithChange() ({{.FieldType}}, bool) {
+	{{- template "rlock" $ }}
     return w.{{$typeName}}.{{.OriginalName}}, w.changes.{{.FieldNameLowerCamel}}Changed
 }
 
 // Set{{.FieldNameUpperCamel}} sets the value of {{$typeName}}.{{.OriginalName}}
 func (w *{{$wrapperName}}) Set{{.FieldNameUpperCamel}}(value {{.FieldType}}) {
+	{{- template "mlock" $ }}
+    {{- if $.OnChangeHooks }}
+    oldValue := w.{{$typeName}}.{{.OriginalName}}
+    {{- end }}
     w.{{$typeName}}.{{.OriginalName}} = value
     w.changes.{{.FieldNameLowerCamel}}Changed = true
+	{{- template "unlock" $ }}
+    {{- if $.OnChangeHooks }}
+    w.notifyChange("{{.OriginalName}}", oldValue, value)
+    {{- end }}
+}
+
+{{- if and $.ThreadSafe (eq .IsComparable "true") }}
+// CompareAndSet{{.FieldNameUpperCamel}} sets the value of {{$typeName}}.{{.OriginalName}} only if its current value equals expected
+// It returns true if the value was set
+func (w *{{$wrapperName}}) CompareAndSet{{.FieldNameUpperCamel}}(expected, value {{.FieldType}}) bool {
+	w.mu.Lock()
+	if w.{{$typeName}}.{{.OriginalName}} != expected {
+		w.mu.Unlock()
+		return false
+	}
+	w.{{$typeName}}.{{.OriginalName}} = value
+	w.changes.{{.FieldNameLowerCamel}}Changed = true
+	w.mu.Unlock()
+	{{- if $.OnChangeHooks }}
+	w.notifyChange("{{.OriginalName}}", expected, value)
+	{{- end }}
+	return true
+}
+{{- end }}
+
+{{- if eq .IsTime "true" }}
+// Is{{.FieldNameUpperCamel}}Zero returns true if {{$typeName}}.{{.OriginalName}} is the zero time
+func (w *{{$wrapperName}}) Is{{.FieldNameUpperCamel}}Zero() bool {
+	{{- template "rlock" $ }}
+	return w.{{$typeName}}.{{.OriginalName}}.IsZero()
+}
+
+// Set{{.FieldNameUpperCamel}}Now sets the value of {{$typeName}}.{{.OriginalName}} to the current time
+func (w *{{$wrapperName}}) Set{{.FieldNameUpperCamel}}Now() {
+	w.Set{{.FieldNameUpperCamel}}(time.Now())
+}
+{{ end }}
+
+{{- if and $.NumericHelpers (eq .IsNumeric "true") }}
+// Add{{.FieldNameUpperCamel}} adds delta to {{$typeName}}.{{.OriginalName}} and returns the new value
+func (w *{{$wrapperName}}) Add{{.FieldNameUpperCamel}}(delta {{.FieldType}}) {{.FieldType}} {
+	{{- template "mlock" $ }}
+	{{- if $.OnChangeHooks }}
+	oldValue := w.{{$typeName}}.{{.OriginalName}}
+	{{- end }}
+	w.{{$typeName}}.{{.OriginalName}} += delta
+	w.changes.{{.FieldNameLowerCamel}}Changed = true
+	newValue := w.{{$typeName}}.{{.OriginalName}}
+	{{- template "unlock" $ }}
+	{{- if $.OnChangeHooks }}
+	w.notifyChange("{{.OriginalName}}", oldValue, newValue)
+	{{- end }}
+	return newValue
+}
+
+// Sub{{.FieldNameUpperCamel}} subtracts delta from {{$typeName}}.{{.OriginalName}} and returns the new value
+func (w *{{$wrapperName}}) Sub{{.FieldNameUpperCamel}}(delta {{.FieldType}}) {{.FieldType}} {
+	{{- template "mlock" $ }}
+	{{- if $.OnChangeHooks }}
+	oldValue := w.{{$typeName}}.{{.OriginalName}}
+	{{- end }}
+	w.{{$typeName}}.{{.OriginalName}} -= delta
+	w.changes.{{.FieldNameLowerCamel}}Changed = true
+	newValue := w.{{$typeName}}.{{.OriginalName}}
+	{{- template "unlock" $ }}
+	{{- if $.OnChangeHooks }}
+	w.notifyChange("{{.OriginalName}}", oldValue, newValue)
+	{{- end }}
+	return newValue
 }
+{{ end }}
 
 {{- if eq .IsArray "true" }}
 // GetLast{{.FieldNameUpperCamel}} returns the last value of {{$typeName}}.{{.OriginalName}}
 func (w *{{$wrapperName}}) GetLast{{.FieldNameUpperCamel}}() ({{.ComposedTypeDesc1}}, bool) {
+	{{- template "rlock" $ }}
 	if len(w.{{$typeName}}.{{.OriginalName}}) == 0 {
 		var zero {{.ComposedTypeDesc1}}
 		return zero, false
@@ -62,6 +195,7 @@ func (w *{{$wrapperName}}) GetLast{{.FieldNameUpperCamel}}() ({{.ComposedTypeDes
 
 // GetLast{{.FieldNameUpperCamel}}WithChange returns the last value of {{$typeName}}.{{.OriginalName}} and a boolean indicating if the value has changed
 func (w *{{$wrapperName}}) GetLast{{.FieldNameUpperCamel}}WithChange() ({{.ComposedTypeDesc1}}, bool) {
+	{{- template "rlock" $ }}
 	if len(w.{{$typeName}}.{{.OriginalName}}) == 0 {
 		var zero {{.ComposedTypeDesc1}}
 		return zero, w.changes.{{.FieldNameLowerCamel}}Changed
@@ -71,14 +205,26 @@ func (w *{{$wrapperName}}) GetLast{{.FieldNameUpperCamel}}WithChange() ({{.Compo
 
 // AppendTo{{.FieldNameUpperCamel}} appends a value to {{$typeName}}.{{.OriginalName}}
 func (w *{{$wrapperName}}) AppendTo{{.FieldNameUpperCamel}}(value {{.ComposedTypeDesc1}}) {
+	{{- template "mlock" $ }}
+	{{- if $.OnChangeHooks }}
+	oldValue := w.{{$typeName}}.{{.OriginalName}}
+	{{- end }}
 	w.{{$typeName}}.{{.OriginalName}} = append(w.{{$typeName}}.{{.OriginalName}}, value)
 	w.changes.{{.FieldNameLowerCamel}}Changed = true
+	{{- if $.OnChangeHooks }}
+	newValue := w.{{$typeName}}.{{.OriginalName}}
+	{{- end }}
+	{{- template "unlock" $ }}
+	{{- if $.OnChangeHooks }}
+	w.notifyChange("{{.OriginalName}}", oldValue, newValue)
+	{{- end }}
 }
 {{ end }}
 
 {{- if eq .IsMap "true" }}
 // Init{{.FieldNameUpperCamel}} initializes {{$typeName}}.{{.OriginalName}} if it is nil
 func (w *{{$wrapperName}}) Init{{.FieldNameUpperCamel}}() {
+	{{- template "lock" $ }}
 	if w.{{$typeName}}.{{.OriginalName}} == nil {
 		w.{{$typeName}}.{{.OriginalName}} = make({{.FieldType}})
 	}
@@ -86,27 +232,51 @@ func (w *{{$wrapperName}}) Init{{.FieldNameUpperCamel}}() {
 
 // AddTo{{.FieldNameUpperCamel}} adds a value to {{$typeName}}.{{.OriginalName}}
 func (w *{{$wrapperName}}) AddTo{{.FieldNameUpperCamel}}(key {{.ComposedTypeDesc1}}, value {{.ComposedTypeDesc2}}) {
+	{{- template "mlock" $ }}
 	if w.{{$typeName}}.{{.OriginalName}} == nil {
+		{{- if $.ThreadSafe }}
+		w.{{$typeName}}.{{.OriginalName}} = make({{.FieldType}})
+		{{- else }}
 		w.Init{{.FieldNameUpperCamel}}()
+		{{- end }}
+	}
+	{{- if $.OnChangeHooks }}
+	var oldValue any
+	if v, ok := w.{{$typeName}}.{{.OriginalName}}[key]; ok {
+		oldValue = v
 	}
+	{{- end }}
 	w.{{$typeName}}.{{.OriginalName}}[key] = value
 	w.changes.{{.FieldNameLowerCamel}}Changed = true
+	{{- template "unlock" $ }}
+	{{- if $.OnChangeHooks }}
+	w.notifyChange("{{.OriginalName}}", oldValue, value)
+	{{- end }}
 }
 
 // RemoveFrom{{.FieldNameUpperCamel}} removes a value from {{$typeName}}.{{.OriginalName}}
 func (w *{{$wrapperName}}) RemoveFrom{{.FieldNameUpperCamel}}(key {{.ComposedTypeDesc1}}) {
-	if w.{{$typeName}}.{{.OriginalName}} == nil {
-		return
-	}
-
-	if _, ok := w.{{$typeName}}.{{.OriginalName}}[key]; ok {
+	{{- template "mlock" $ }}
+	{{- if $.OnChangeHooks }}
+	oldValue, ok := w.{{$typeName}}.{{.OriginalName}}[key]
+	{{- else }}
+	_, ok := w.{{$typeName}}.{{.OriginalName}}[key]
+	{{- end }}
+	if ok {
 		delete(w.{{$typeName}}.{{.OriginalName}}, key)
 		w.changes.{{.FieldNameLowerCamel}}Changed = true
 	}
+	{{- template "unlock" $ }}
+	{{- if $.OnChangeHooks }}
+	if ok {
+		w.notifyChange("{{.OriginalName}}", oldValue, nil)
+	}
+	{{- end }}
 }
 
 // Get{{.FieldNameUpperCamel}}Value returns the value of {{$typeName}}.{{.OriginalName}} for the given key
 func (w *{{$wrapperName}}) Get{{.FieldNameUpperCamel}}Value(key {{.ComposedTypeDesc1}}) ({{.ComposedTypeDesc2}}, bool) {
+	{{- template "rlock" $ }}
 	if w.{{$typeName}}.{{.OriginalName}} == nil {
 		var zero {{.ComposedTypeDesc2}}
 		return zero, false
@@ -119,11 +289,21 @@ func (w *{{$wrapperName}}) Get{{.FieldNameUpperCamel}}Value(key {{.ComposedTypeD
 {{- if eq .IsPtr "true" }}
 // Is{{.FieldNameUpperCamel}}Nil returns true if {{$typeName}}.{{.OriginalName}} is nil
 func (w *{{$wrapperName}}) Is{{.FieldNameUpperCamel}}Nil() bool {
+	{{- if $.ThreadSafe }}
+	if w == nil {
+		return true
+	}
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.{{$typeName}}.{{.OriginalName}} == nil
+	{{- else }}
 	return w == nil || w.{{$typeName}}.{{.OriginalName}} == nil
+	{{- end }}
 }
 
 // Get{{.FieldNameUpperCamel}}Value returns the value of {{$typeName}}.{{.OriginalName}} and a boolean indicating if the value is not nil
 func (w *{{$wrapperName}}) Get{{.FieldNameUpperCamel}}Value() ({{.PtrFieldType}}, bool) {
+	{{- template "rlock" $ }}
 	if w.{{$typeName}}.{{.OriginalName}} == nil {
 		var zero {{.PtrFieldType}}
 		return zero, false
@@ -133,6 +313,7 @@ func (w *{{$wrapperName}}) Get{{.FieldNameUpperCamel}}Value() ({{.PtrFieldType}}
 
 // Get{{.FieldNameUpperCamel}}OrZeroValue returns the value of {{$typeName}}.{{.OriginalName}} and a zero value if the value is nil
 func (w *{{$wrapperName}}) Get{{.FieldNameUpperCamel}}OrZeroValue() {{.PtrFieldType}} {
+	{{- template "rlock" $ }}
 	if w.{{$typeName}}.{{.OriginalName}} == nil {
 		var zero {{.PtrFieldType}}
 		return zero
@@ -158,10 +339,20 @@ func New{{$wrapperName}}Builder() *{{$wrapperName}}Builder {
     return &{{$wrapperName}}Builder{wrapper: New{{$wrapperName}}()}
 }
 
+{{- if .ValidateOnBuild }}
+// Build validates {{$typeName}} against its 'validate' tags and returns the built {{$wrapperName}}
+func (b *{{$wrapperName}}Builder) Build() (*{{$wrapperName}}, error) {
+    if err := structGuardValidator.Struct(b.wrapper.{{$typeName}}); err != nil {
+        return nil, err
+    }
+    return b.wrapper, nil
+}
+{{- else }}
 // Build returns the built {{$wrapperName}}
 func (b *{{$wrapperName}}Builder) Build() *{{$wrapperName}} {
     return b.wrapper
 }
+{{- end }}
 
 {{- range .Fields }}
 // With{{.FieldNameUpperCamel}} sets the value of {{$typeName}}.{{.OriginalName}} and returns the builder
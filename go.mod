@@ -1,6 +1,6 @@
 module github.com/rendis/devtoolkit
 
-go 1.22
+go 1.23
 
 require (
 	github.com/go-playground/validator/v10 v10.22.0
@@ -0,0 +1,365 @@
+package devtoolkit
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrCycleDetected is returned (wrapped in a CycleError) when a graph that must be acyclic contains a cycle.
+	ErrCycleDetected = errors.New("cycle detected")
+
+	// ErrUndirectedGraph is returned by operations that are only defined for directed graphs.
+	ErrUndirectedGraph = errors.New("operation requires a directed graph")
+)
+
+// CycleError reports a cycle found in a graph.
+// Cycle holds the nodes of the cycle in edge order, with the first node repeated at the end.
+type CycleError[T comparable] struct {
+	Cycle []T
+}
+
+func (e *CycleError[T]) Error() string {
+	var parts = make([]string, len(e.Cycle))
+	for i, n := range e.Cycle {
+		parts[i] = fmt.Sprint(n)
+	}
+	return fmt.Sprintf("%s: %s", ErrCycleDetected, strings.Join(parts, " -> "))
+}
+
+func (e *CycleError[T]) Unwrap() error {
+	return ErrCycleDetected
+}
+
+// TopoSort returns nodes in topological order, where each edge (First, Second) means First must come before Second.
+// The sort is stable: when several nodes are ready at the same time they keep their relative input order.
+// Nodes only referenced by edges are included after the given nodes, in order of first appearance.
+// If the graph contains a cycle, a *CycleError describing the cycle path is returned.
+func TopoSort[T comparable](nodes []T, edges []Pair[T, T]) ([]T, error) {
+	var order = make(map[T]int, len(nodes))
+	var all []T
+	var register = func(n T) {
+		if _, ok := order[n]; !ok {
+			order[n] = len(all)
+			all = append(all, n)
+		}
+	}
+
+	for _, n := range nodes {
+		register(n)
+	}
+
+	var successors = make(map[T][]T)
+	var predecessors = make(map[T][]T)
+	var inDegree = make(map[T]int)
+	for _, e := range edges {
+		register(e.First)
+		register(e.Second)
+		successors[e.First] = append(successors[e.First], e.Second)
+		predecessors[e.Second] = append(predecessors[e.Second], e.First)
+		inDegree[e.Second]++
+	}
+
+	var ready = &intHeap{}
+	for i, n := range all {
+		if inDegree[n] == 0 {
+			heap.Push(ready, i)
+		}
+	}
+
+	var sorted = make([]T, 0, len(all))
+	for ready.Len() > 0 {
+		n := all[heap.Pop(ready).(int)]
+		sorted = append(sorted, n)
+		for _, s := range successors[n] {
+			inDegree[s]--
+			if inDegree[s] == 0 {
+				heap.Push(ready, order[s])
+			}
+		}
+	}
+
+	if len(sorted) == len(all) {
+		return sorted, nil
+	}
+
+	return nil, &CycleError[T]{Cycle: findCycle(all, predecessors, inDegree)}
+}
+
+// findCycle walks backwards through the predecessors of the nodes that could not be sorted
+// (those with a remaining in-degree) until a node repeats, and returns that cycle in edge order.
+func findCycle[T comparable](all []T, predecessors map[T][]T, inDegree map[T]int) []T {
+	var start T
+	for _, n := range all {
+		if inDegree[n] > 0 {
+			start = n
+			break
+		}
+	}
+
+	var visitedAt = make(map[T]int)
+	var path []T
+	current := start
+	for {
+		if i, ok := visitedAt[current]; ok {
+			path = path[i:]
+			break
+		}
+		visitedAt[current] = len(path)
+		path = append(path, current)
+		for _, p := range predecessors[current] {
+			if inDegree[p] > 0 {
+				current = p
+				break
+			}
+		}
+	}
+
+	// path was built following predecessors, reverse it to follow edge direction and close the cycle.
+	Reverse(path)
+	return append(path, path[0])
+}
+
+// GraphIterator defines a function type for iterating over the nodes of a Graph.
+type GraphIterator[T any] func(yield func(T) bool)
+
+// Graph is a lightweight generic graph of comparable nodes, either directed or undirected.
+// Nodes and neighbors are kept in insertion order, so traversals are deterministic.
+// Graph is not safe for concurrent use.
+type Graph[T comparable] struct {
+	directed     bool
+	nodes        []T
+	successors   map[T][]T
+	predecessors map[T][]T
+	edges        map[T]map[T]struct{}
+}
+
+// NewGraph creates a new empty Graph. If directed is false, every edge is added in both directions.
+func NewGraph[T comparable](directed bool) *Graph[T] {
+	return &Graph[T]{
+		directed:     directed,
+		successors:   make(map[T][]T),
+		predecessors: make(map[T][]T),
+		edges:        make(map[T]map[T]struct{}),
+	}
+}
+
+// IsDirected returns true if the graph is directed.
+func (g *Graph[T]) IsDirected() bool {
+	return g.directed
+}
+
+// AddNode adds a node to the graph, if not already present.
+func (g *Graph[T]) AddNode(node T) {
+	if _, ok := g.edges[node]; ok {
+		return
+	}
+	g.nodes = append(g.nodes, node)
+	g.edges[node] = make(map[T]struct{})
+}
+
+// AddEdge adds an edge between from and to, adding the nodes if needed.
+// Duplicated edges are ignored.
+func (g *Graph[T]) AddEdge(from, to T) {
+	g.AddNode(from)
+	g.AddNode(to)
+	g.addArc(from, to)
+	if !g.directed {
+		g.addArc(to, from)
+	}
+}
+
+func (g *Graph[T]) addArc(from, to T) {
+	if _, ok := g.edges[from][to]; ok {
+		return
+	}
+	g.edges[from][to] = struct{}{}
+	g.successors[from] = append(g.successors[from], to)
+	g.predecessors[to] = append(g.predecessors[to], from)
+}
+
+// HasNode returns true if node is in the graph.
+func (g *Graph[T]) HasNode(node T) bool {
+	_, ok := g.edges[node]
+	return ok
+}
+
+// HasEdge returns true if there is an edge from 'from' to 'to'.
+func (g *Graph[T]) HasEdge(from, to T) bool {
+	_, ok := g.edges[from][to]
+	return ok
+}
+
+// Nodes returns all nodes in insertion order.
+func (g *Graph[T]) Nodes() []T {
+	var nodes = make([]T, len(g.nodes))
+	copy(nodes, g.nodes)
+	return nodes
+}
+
+// Edges returns all edges as (from, to) pairs. In undirected graphs each edge is returned in both directions.
+func (g *Graph[T]) Edges() []Pair[T, T] {
+	var edges []Pair[T, T]
+	for _, n := range g.nodes {
+		for _, s := range g.successors[n] {
+			edges = append(edges, NewPair(n, s))
+		}
+	}
+	return edges
+}
+
+// Neighbors returns the nodes reachable from node through a single edge, in insertion order.
+func (g *Graph[T]) Neighbors(node T) []T {
+	var neighbors = make([]T, len(g.successors[node]))
+	copy(neighbors, g.successors[node])
+	return neighbors
+}
+
+// BFS returns an iterator over the nodes reachable from start in breadth-first order, start included.
+// The iterator yields nothing if start is not in the graph.
+func (g *Graph[T]) BFS(start T) GraphIterator[T] {
+	return func(yield func(T) bool) {
+		if !g.HasNode(start) {
+			return
+		}
+
+		var visited = map[T]bool{start: true}
+		var queue = []T{start}
+		for len(queue) > 0 {
+			n := queue[0]
+			queue = queue[1:]
+			if !yield(n) {
+				return
+			}
+			for _, s := range g.successors[n] {
+				if !visited[s] {
+					visited[s] = true
+					queue = append(queue, s)
+				}
+			}
+		}
+	}
+}
+
+// DFS returns an iterator over the nodes reachable from start in depth-first (pre-)order, start included.
+// The iterator yields nothing if start is not in the graph.
+func (g *Graph[T]) DFS(start T) GraphIterator[T] {
+	return func(yield func(T) bool) {
+		if !g.HasNode(start) {
+			return
+		}
+
+		var visited = make(map[T]bool)
+		var stack = []T{start}
+		for len(stack) > 0 {
+			n := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if visited[n] {
+				continue
+			}
+			visited[n] = true
+			if !yield(n) {
+				return
+			}
+
+			// push in reverse so neighbors are visited in insertion order
+			successors := g.successors[n]
+			for i := len(successors) - 1; i >= 0; i-- {
+				if !visited[successors[i]] {
+					stack = append(stack, successors[i])
+				}
+			}
+		}
+	}
+}
+
+// ShortestPath returns the path with the fewest edges from 'from' to 'to', both included.
+// Returns false if 'to' is not reachable from 'from'.
+func (g *Graph[T]) ShortestPath(from, to T) ([]T, bool) {
+	if !g.HasNode(from) || !g.HasNode(to) {
+		return nil, false
+	}
+
+	var parent = map[T]T{}
+	var visited = map[T]bool{from: true}
+	var queue = []T{from}
+	for len(queue) > 0 && !visited[to] {
+		n := queue[0]
+		queue = queue[1:]
+		for _, s := range g.successors[n] {
+			if !visited[s] {
+				visited[s] = true
+				parent[s] = n
+				queue = append(queue, s)
+			}
+		}
+	}
+
+	if !visited[to] {
+		return nil, false
+	}
+
+	var path = []T{to}
+	for n := to; n != from; {
+		n = parent[n]
+		path = append(path, n)
+	}
+	Reverse(path)
+	return path, true
+}
+
+// ConnectedComponents returns the connected components of the graph, each one in discovery order.
+// For directed graphs, edge direction is ignored (weakly connected components).
+func (g *Graph[T]) ConnectedComponents() [][]T {
+	var visited = make(map[T]bool)
+	var components [][]T
+	for _, start := range g.nodes {
+		if visited[start] {
+			continue
+		}
+
+		visited[start] = true
+		var component []T
+		var queue = []T{start}
+		for len(queue) > 0 {
+			n := queue[0]
+			queue = queue[1:]
+			component = append(component, n)
+			for _, adjacent := range [][]T{g.successors[n], g.predecessors[n]} {
+				for _, a := range adjacent {
+					if !visited[a] {
+						visited[a] = true
+						queue = append(queue, a)
+					}
+				}
+			}
+		}
+		components = append(components, component)
+	}
+	return components
+}
+
+// TopoSort returns the nodes of a directed graph in stable topological order. See TopoSort.
+func (g *Graph[T]) TopoSort() ([]T, error) {
+	if !g.directed {
+		return nil, ErrUndirectedGraph
+	}
+	return TopoSort(g.nodes, g.Edges())
+}
+
+// intHeap is a min-heap of ints used to keep topological sorting stable.
+type intHeap []int
+
+func (h intHeap) Len() int           { return len(h) }
+func (h intHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h intHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *intHeap) Push(x any)        { *h = append(*h, x.(int)) }
+func (h *intHeap) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
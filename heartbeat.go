@@ -0,0 +1,145 @@
+package devtoolkit
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const defaultHeartbeatInterval = 10 * time.Second
+
+// HeartbeatStats is the progress of a job reported by Heartbeat on each beat.
+type HeartbeatStats struct {
+	Beat      int           // number of the beat, starting at 1
+	Elapsed   time.Duration // time since the heartbeat started
+	Processed int64         // items processed so far
+	Failed    int64         // items failed so far
+	Rate      float64       // items processed per second since the previous beat
+	Status    string        // last status set, e.g. the running link or the file being imported
+	Final     bool          // true for the last beat, sent when the heartbeat stops
+}
+
+// HeartbeatMonitor tracks the progress of a job reported by Heartbeat.
+// Its methods are safe for concurrent use, e.g. by several workers.
+type HeartbeatMonitor struct {
+	fn        func(HeartbeatStats)
+	started   time.Time
+	processed atomic.Int64
+	failed    atomic.Int64
+	status    atomic.Value
+
+	// state of the previous beat, only accessed by the beating goroutine
+	beat          int
+	lastBeat      time.Time
+	lastProcessed int64
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// Heartbeat calls fn every interval (10s if not positive) with the progress of a long-running job, such as a process
+// chain, a pool of workers or a CSV import, updated through the returned HeartbeatMonitor.
+// It stops when ctx is done or Stop is called, calling fn a last time with Final set.
+// fn is called from a single goroutine, so it doesn't need to be safe for concurrent use.
+func Heartbeat(ctx context.Context, interval time.Duration, fn func(stats HeartbeatStats)) *HeartbeatMonitor {
+	if interval <= 0 {
+		interval = defaultHeartbeatInterval
+	}
+
+	var now = time.Now()
+	var m = &HeartbeatMonitor{
+		fn:       fn,
+		started:  now,
+		lastBeat: now,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	m.status.Store("")
+
+	go m.run(ctx, interval)
+	return m
+}
+
+// Add adds n processed items.
+func (m *HeartbeatMonitor) Add(n int64) {
+	m.processed.Add(n)
+}
+
+// Fail adds n failed items.
+func (m *HeartbeatMonitor) Fail(n int64) {
+	m.failed.Add(n)
+}
+
+// SetStatus sets the status reported on the next beats.
+func (m *HeartbeatMonitor) SetStatus(status string) {
+	m.status.Store(status)
+}
+
+// Stop stops the heartbeat, waiting for the final beat to be reported. It can be called several times.
+func (m *HeartbeatMonitor) Stop() {
+	m.stopOnce.Do(func() {
+		close(m.stop)
+	})
+	<-m.done
+}
+
+// Done returns a channel closed after the final beat has been reported.
+func (m *HeartbeatMonitor) Done() <-chan struct{} {
+	return m.done
+}
+
+func (m *HeartbeatMonitor) run(ctx context.Context, interval time.Duration) {
+	defer close(m.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			m.report(true)
+			return
+		case <-m.stop:
+			m.report(true)
+			return
+		case <-ticker.C:
+			m.report(false)
+		}
+	}
+}
+
+// report calls fn with the current stats.
+func (m *HeartbeatMonitor) report(final bool) {
+	var now = time.Now()
+	var processed = m.processed.Load()
+
+	m.beat++
+	var stats = HeartbeatStats{
+		Beat:      m.beat,
+		Elapsed:   now.Sub(m.started),
+		Processed: processed,
+		Failed:    m.failed.Load(),
+		Status:    m.status.Load().(string),
+		Final:     final,
+	}
+	if elapsed := now.Sub(m.lastBeat).Seconds(); elapsed > 0 {
+		stats.Rate = float64(processed-m.lastProcessed) / elapsed
+	}
+	m.lastBeat, m.lastProcessed = now, processed
+
+	m.fn(stats)
+}
+
+// LogHeartbeat returns a Heartbeat callback logging the stats with the standard logger, prefixed by name.
+func LogHeartbeat(name string) func(HeartbeatStats) {
+	return func(s HeartbeatStats) {
+		var state = "running"
+		if s.Final {
+			state = "stopped"
+		}
+		log.Printf("%s %s: beat=%d elapsed=%s processed=%d failed=%d rate=%.1f/s status=%q",
+			name, state, s.Beat, s.Elapsed.Round(time.Millisecond), s.Processed, s.Failed, s.Rate, s.Status)
+	}
+}
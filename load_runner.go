@@ -0,0 +1,186 @@
+package devtoolkit
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	ErrNilLoadScenarioFn  = errors.New("nil load scenario function")
+	ErrInvalidLoadOptions = errors.New("invalid load options: Duration must be positive and RPS or Concurrency must be set")
+)
+
+// LoadScenarioFn is the operation executed repeatedly by RunLoad.
+type LoadScenarioFn func(ctx context.Context) error
+
+// LoadOptions contains configuration parameters for RunLoad.
+type LoadOptions struct {
+	Duration    time.Duration // indicates how long the load is generated. Required.
+	RPS         float64       // indicates the target requests per second. If zero, requests are issued back to back by Concurrency goroutines.
+	Concurrency int           // indicates the number of concurrent callers, or the maximum in-flight requests when RPS is set. Default is ceil(RPS) when RPS is set.
+}
+
+// LoadReport holds the results of a RunLoad execution.
+type LoadReport struct {
+	Requests    int           // total number of executed requests
+	Errors      int           // number of requests that returned an error
+	ErrorRate   float64       // Errors / Requests
+	Elapsed     time.Duration // total execution time
+	AchievedRPS float64       // Requests / Elapsed
+	Min         time.Duration
+	Max         time.Duration
+	Mean        time.Duration
+	P50         time.Duration
+	P90         time.Duration
+	P95         time.Duration
+	P99         time.Duration
+	latencies   []time.Duration // sorted
+}
+
+// Percentile returns the latency below which the given percentage (0-100) of requests fall.
+func (r *LoadReport) Percentile(p float64) time.Duration {
+	return latencyPercentile(r.latencies, p)
+}
+
+// RunLoad runs fn during opts.Duration, either at a target rate (opts.RPS) or as fast as possible
+// with opts.Concurrency concurrent callers, and returns a report with latency percentiles and error rate.
+// The context passed to fn is cancelled when the duration elapses or ctx is done.
+// When RPS is set and all Concurrency slots are busy, dispatching waits for a slot to be released,
+// so the achieved rate can be lower than the target.
+func RunLoad(ctx context.Context, opts LoadOptions, fn LoadScenarioFn) (*LoadReport, error) {
+	if ctx == nil {
+		return nil, ConcurrentExecNilContextErr
+	}
+
+	if fn == nil {
+		return nil, ErrNilLoadScenarioFn
+	}
+
+	if opts.Duration <= 0 || opts.RPS < 0 || opts.Concurrency < 0 || (opts.RPS == 0 && opts.Concurrency == 0) {
+		return nil, ErrInvalidLoadOptions
+	}
+
+	if opts.RPS > 0 && opts.Concurrency == 0 {
+		opts.Concurrency = int(math.Ceil(opts.RPS))
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, opts.Duration)
+	defer cancel()
+
+	var collector = &latencyCollector{}
+	var call = func() {
+		start := time.Now()
+		err := fn(runCtx)
+		collector.add(time.Since(start), err)
+	}
+
+	start := time.Now()
+	if opts.RPS > 0 {
+		runAtRate(runCtx, opts, call)
+	} else {
+		runBackToBack(runCtx, opts, call)
+	}
+
+	return collector.report(time.Since(start)), nil
+}
+
+func runAtRate(ctx context.Context, opts LoadOptions, call func()) {
+	interval := time.Duration(float64(time.Second) / opts.RPS)
+	if interval <= 0 {
+		interval = 1
+	}
+
+	var workers = NewConcurrentWorkers(opts.Concurrency)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			workers.Wait()
+			return
+		case <-ticker.C:
+			workers.Execute(call)
+		}
+	}
+}
+
+func runBackToBack(ctx context.Context, opts LoadOptions, call func()) {
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				call()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// latencyCollector accumulates latencies and errors from concurrent callers.
+type latencyCollector struct {
+	mu        sync.Mutex
+	latencies []time.Duration
+	errors    int
+}
+
+func (c *latencyCollector) add(latency time.Duration, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.latencies = append(c.latencies, latency)
+	if err != nil {
+		c.errors++
+	}
+}
+
+func (c *latencyCollector) report(elapsed time.Duration) *LoadReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var report = &LoadReport{
+		Requests:  len(c.latencies),
+		Errors:    c.errors,
+		Elapsed:   elapsed,
+		latencies: c.latencies,
+	}
+
+	if report.Requests == 0 {
+		return report
+	}
+
+	sort.Slice(report.latencies, func(i, j int) bool {
+		return report.latencies[i] < report.latencies[j]
+	})
+
+	var total time.Duration
+	for _, l := range report.latencies {
+		total += l
+	}
+
+	report.ErrorRate = float64(report.Errors) / float64(report.Requests)
+	report.AchievedRPS = float64(report.Requests) / elapsed.Seconds()
+	report.Min = report.latencies[0]
+	report.Max = report.latencies[len(report.latencies)-1]
+	report.Mean = total / time.Duration(report.Requests)
+	report.P50 = latencyPercentile(report.latencies, 50)
+	report.P90 = latencyPercentile(report.latencies, 90)
+	report.P95 = latencyPercentile(report.latencies, 95)
+	report.P99 = latencyPercentile(report.latencies, 99)
+	return report
+}
+
+// latencyPercentile returns the nearest-rank percentile of the sorted latencies.
+func latencyPercentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	p = max(0, min(p, 100))
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank-1, 0)]
+}
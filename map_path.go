@@ -0,0 +1,159 @@
+package devtoolkit
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidPath is returned by SetPath when the path is malformed or crosses a value that is not a map or a slice.
+var ErrInvalidPath = errors.New("invalid path")
+
+// pathSegment is a map key or a slice index of a path.
+type pathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// GetPath returns the value found at the given path of nested maps and slices, as decoded from JSON or YAML
+// or returned by StructToMap. The path is made of dot-separated keys and bracketed slice indexes, e.g. "a.b[2].c".
+// Returns false if the path is malformed or doesn't exist.
+func GetPath(m map[string]any, path string) (any, bool) {
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var current any = m
+	for _, seg := range segments {
+		if seg.isIndex {
+			s, ok := current.([]any)
+			if !ok || seg.index >= len(s) {
+				return nil, false
+			}
+			current = s[seg.index]
+			continue
+		}
+
+		mm, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if current, ok = mm[seg.key]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// SetPath sets the value at the given path of nested maps and slices, using the same syntax as GetPath.
+// Missing maps and slices along the path are created, and an index equal to the length of a slice appends
+// to it. Returns an error wrapping ErrInvalidPath if the path is malformed, an index is greater than the length
+// of its slice, so untrusted paths can't allocate huge slices, or an intermediate value is not of the expected kind.
+func SetPath(m map[string]any, path string, value any) error {
+	if m == nil {
+		return fmt.Errorf("%w: nil map", ErrInvalidPath)
+	}
+
+	segments, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+
+	_, err = setPath(m, segments, 0, value)
+	return err
+}
+
+// setPath sets value at segments[i:] of node and returns the updated node, which is a new value
+// when node is nil or a slice had to be appended to.
+func setPath(node any, segments []pathSegment, i int, value any) (any, error) {
+	if i == len(segments) {
+		return value, nil
+	}
+
+	var seg = segments[i]
+	if seg.isIndex {
+		s, ok := node.([]any)
+		if !ok && node != nil {
+			return nil, fmt.Errorf("%w: '%s' is %T, not a slice", ErrInvalidPath, formatPath(segments[:i]), node)
+		}
+		if seg.index > len(s) {
+			return nil, fmt.Errorf("%w: index %d of '%s' is greater than its length %d",
+				ErrInvalidPath, seg.index, formatPath(segments[:i]), len(s))
+		}
+		if seg.index == len(s) {
+			s = append(s, nil)
+		}
+
+		child, err := setPath(s[seg.index], segments, i+1, value)
+		if err != nil {
+			return nil, err
+		}
+		s[seg.index] = child
+		return s, nil
+	}
+
+	mm, ok := node.(map[string]any)
+	if !ok && node != nil {
+		return nil, fmt.Errorf("%w: '%s' is %T, not a map", ErrInvalidPath, formatPath(segments[:i]), node)
+	}
+	if mm == nil {
+		mm = make(map[string]any)
+	}
+
+	child, err := setPath(mm[seg.key], segments, i+1, value)
+	if err != nil {
+		return nil, err
+	}
+	mm[seg.key] = child
+	return mm, nil
+}
+
+// parsePath splits a path such as "a.b[2].c" into its segments.
+func parsePath(path string) ([]pathSegment, error) {
+	var segments []pathSegment
+	for _, part := range strings.Split(path, ".") {
+		key, rest, hasIndex := strings.Cut(part, "[")
+		if key == "" {
+			return nil, fmt.Errorf("%w: empty key in '%s'", ErrInvalidPath, path)
+		}
+		if strings.Contains(key, "]") {
+			return nil, fmt.Errorf("%w: unexpected ']' in '%s'", ErrInvalidPath, path)
+		}
+		segments = append(segments, pathSegment{key: key})
+
+		if !hasIndex {
+			continue
+		}
+		for _, idx := range strings.Split(rest, "[") {
+			n, ok := strings.CutSuffix(idx, "]")
+			if !ok {
+				return nil, fmt.Errorf("%w: unterminated index in '%s'", ErrInvalidPath, path)
+			}
+			index, err := strconv.Atoi(n)
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("%w: invalid index '%s' in '%s'", ErrInvalidPath, n, path)
+			}
+			segments = append(segments, pathSegment{index: index, isIndex: true})
+		}
+	}
+	return segments, nil
+}
+
+// formatPath returns the string form of the given segments.
+func formatPath(segments []pathSegment) string {
+	var sb strings.Builder
+	for _, seg := range segments {
+		if seg.isIndex {
+			sb.WriteString("[" + strconv.Itoa(seg.index) + "]")
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteByte('.')
+		}
+		sb.WriteString(seg.key)
+	}
+	return sb.String()
+}
@@ -0,0 +1,116 @@
+package devtoolkit
+
+import "fmt"
+
+// MapKeys returns a new slice containing all keys from the given map.
+// Note that the order of keys is not guaranteed.
+func MapKeys[K comparable, V any](m map[K]V) []K {
+	var keys = make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// MapValues returns a new slice containing all values from the given map.
+// Note that the order of values is not guaranteed.
+func MapValues[K comparable, V any](m map[K]V) []V {
+	var values = make([]V, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return values
+}
+
+// MapEntries returns a new slice containing all entries from the given map as key-value pairs.
+// Note that the order of entries is not guaranteed.
+func MapEntries[K comparable, V any](m map[K]V) []Pair[K, V] {
+	var entries = make([]Pair[K, V], 0, len(m))
+	for k, v := range m {
+		entries = append(entries, NewPair(k, v))
+	}
+	return entries
+}
+
+// FromEntries returns a new map containing the given key-value pairs.
+// When several entries have the same key, the last one wins.
+func FromEntries[K comparable, V any](entries []Pair[K, V]) map[K]V {
+	var m = make(map[K]V, len(entries))
+	for _, e := range entries {
+		m[e.First] = e.Second
+	}
+	return m
+}
+
+// MergeMaps copies all entries from src into dst and returns dst, allocating a new map if dst is nil.
+// When a key is present in both maps, onConflict receives the key, the dst value and the src value and returns
+// the value to keep. If onConflict is nil, the src value wins.
+func MergeMaps[K comparable, V any](dst, src map[K]V, onConflict func(K, V, V) V) map[K]V {
+	if dst == nil {
+		dst = make(map[K]V, len(src))
+	}
+	for k, v := range src {
+		if existing, ok := dst[k]; ok && onConflict != nil {
+			v = onConflict(k, existing, v)
+		}
+		dst[k] = v
+	}
+	return dst
+}
+
+// Merge returns a new map containing the entries of all the given maps.
+// When a key is present in several maps, the value of the last one wins.
+func Merge[K comparable, V any](maps ...map[K]V) map[K]V {
+	var size int
+	for _, m := range maps {
+		size += len(m)
+	}
+
+	var merged = make(map[K]V, size)
+	for _, m := range maps {
+		MergeMaps(merged, m, nil)
+	}
+	return merged
+}
+
+// FilterMap returns a new map containing the entries of m for which predicate returns true.
+func FilterMap[K comparable, V any](m map[K]V, predicate func(K, V) bool) map[K]V {
+	var filtered = make(map[K]V)
+	for k, v := range m {
+		if predicate(k, v) {
+			filtered[k] = v
+		}
+	}
+	return filtered
+}
+
+// TransformValues returns a new map with the keys of m and the values returned by fn for each entry.
+func TransformValues[K comparable, V, R any](m map[K]V, fn func(K, V) R) map[K]R {
+	var transformed = make(map[K]R, len(m))
+	for k, v := range m {
+		transformed[k] = fn(k, v)
+	}
+	return transformed
+}
+
+// TransformKeys returns a new map with the keys returned by fn for each entry of m and their values,
+// handling the entries mapped to the same key according to policy.
+// Since map iteration order is not defined, DuplicateKeyKeepFirst and DuplicateKeyKeepLast keep an unspecified one
+// of the colliding values; use DuplicateKeyError when the result must be deterministic.
+// Returns an error wrapping ErrDuplicateKey if policy is DuplicateKeyError and a key is repeated.
+func TransformKeys[K, R comparable, V any](m map[K]V, fn func(K, V) R, policy DuplicateKeyPolicy) (map[R]V, error) {
+	var transformed = make(map[R]V, len(m))
+	for k, v := range m {
+		key := fn(k, v)
+		if _, ok := transformed[key]; ok {
+			switch policy {
+			case DuplicateKeyKeepFirst:
+				continue
+			case DuplicateKeyError:
+				return nil, fmt.Errorf("%w: %v", ErrDuplicateKey, key)
+			}
+		}
+		transformed[key] = v
+	}
+	return transformed, nil
+}
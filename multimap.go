@@ -0,0 +1,132 @@
+package devtoolkit
+
+import (
+	"iter"
+	"slices"
+)
+
+// MultiMap is a generic map from a key to a list of values, keeping the order of the keys by first insertion
+// and the order of the values of each key.
+// The zero value is an empty map ready to use. A MultiMap is not safe for concurrent use.
+type MultiMap[K comparable, V any] struct {
+	values map[K][]V
+	keys   []K
+}
+
+// NewMultiMap returns a new empty MultiMap.
+func NewMultiMap[K comparable, V any]() *MultiMap[K, V] {
+	return &MultiMap[K, V]{values: make(map[K][]V)}
+}
+
+// MultiMapFrom returns a new MultiMap containing a copy of the values of m, such as the result of GroupBy
+// or of the CSV reader GroupBy* methods. Keys are ordered as returned by ranging over m.
+func MultiMapFrom[K comparable, V any](m map[K][]V) *MultiMap[K, V] {
+	var mm = &MultiMap[K, V]{values: make(map[K][]V, len(m))}
+	for k, values := range m {
+		mm.Add(k, values...)
+	}
+	return mm
+}
+
+// Add appends the given values to the values of key.
+func (mm *MultiMap[K, V]) Add(key K, values ...V) {
+	if len(values) == 0 {
+		return
+	}
+	if mm.values == nil {
+		mm.values = make(map[K][]V)
+	}
+	if _, ok := mm.values[key]; !ok {
+		mm.keys = append(mm.keys, key)
+	}
+	mm.values[key] = append(mm.values[key], values...)
+}
+
+// Get returns the values of key, or nil if key is not present. The returned slice must not be modified.
+func (mm *MultiMap[K, V]) Get(key K) []V {
+	return mm.values[key]
+}
+
+// Has returns true if key has at least one value.
+func (mm *MultiMap[K, V]) Has(key K) bool {
+	_, ok := mm.values[key]
+	return ok
+}
+
+// RemoveValue removes the values of key for which predicate returns true, keeping the order of the remaining ones.
+// The key is removed when it has no values left. Returns the number of removed values.
+func (mm *MultiMap[K, V]) RemoveValue(key K, predicate func(V) bool) int {
+	values, ok := mm.values[key]
+	if !ok {
+		return 0
+	}
+
+	var kept = slices.DeleteFunc(values, predicate)
+	if len(kept) == 0 {
+		mm.RemoveKey(key)
+	} else {
+		mm.values[key] = kept
+	}
+	return len(values) - len(kept)
+}
+
+// RemoveKey removes key and all its values. Returns true if key was present.
+func (mm *MultiMap[K, V]) RemoveKey(key K) bool {
+	if _, ok := mm.values[key]; !ok {
+		return false
+	}
+	delete(mm.values, key)
+	mm.keys = slices.DeleteFunc(mm.keys, func(k K) bool { return k == key })
+	return true
+}
+
+// Keys returns a new slice containing the keys, in order of first insertion.
+func (mm *MultiMap[K, V]) Keys() []K {
+	return slices.Clone(mm.keys)
+}
+
+// Len returns the number of keys.
+func (mm *MultiMap[K, V]) Len() int {
+	return len(mm.keys)
+}
+
+// Size returns the total number of values of all the keys.
+func (mm *MultiMap[K, V]) Size() int {
+	var size int
+	for _, values := range mm.values {
+		size += len(values)
+	}
+	return size
+}
+
+// All returns a sequence over the keys, in order of first insertion, and their values.
+// The map must not be modified during the iteration.
+func (mm *MultiMap[K, V]) All() iter.Seq2[K, []V] {
+	return func(yield func(K, []V) bool) {
+		for _, k := range mm.keys {
+			if !yield(k, mm.values[k]) {
+				return
+			}
+		}
+	}
+}
+
+// Flatten returns a new slice with one key-value pair per value, ordered by key and then by value.
+func (mm *MultiMap[K, V]) Flatten() []Pair[K, V] {
+	var pairs = make([]Pair[K, V], 0, mm.Size())
+	for _, k := range mm.keys {
+		for _, v := range mm.values[k] {
+			pairs = append(pairs, NewPair(k, v))
+		}
+	}
+	return pairs
+}
+
+// ToMap returns a new map from each key to a copy of its values.
+func (mm *MultiMap[K, V]) ToMap() map[K][]V {
+	var m = make(map[K][]V, len(mm.values))
+	for k, values := range mm.values {
+		m[k] = slices.Clone(values)
+	}
+	return m
+}
@@ -2,20 +2,94 @@ package devtoolkit
 
 import (
 	"encoding/json"
+	"fmt"
+	"math"
 	"reflect"
+	"strconv"
+	"strings"
+	"time"
 )
 
+// defaultTimeLayouts are the layouts tried by ToTime when none are given.
+var defaultTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	time.DateTime,
+	time.DateOnly,
+	time.RFC1123Z,
+	time.RFC1123,
+}
+
 // ToPtr returns a pointer to the given value.
 func ToPtr[T any](t T) *T {
 	return &t
 }
 
-// IsZero returns true if the given value is the zero value for its type.
+// Must returns v, panicking with err if it is not nil.
+// It is meant for initialization code where failing fast is desired, e.g. Must(template.ParseFiles("index.html")).
+func Must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Must2 returns a and b, panicking with err if it is not nil.
+func Must2[T, U any](a T, b U, err error) (T, U) {
+	if err != nil {
+		panic(err)
+	}
+	return a, b
+}
+
+// MustOK returns v, panicking if ok is false, e.g. MustOK(os.LookupEnv("HOME")).
+func MustOK[T any](v T, ok bool) T {
+	if !ok {
+		panic(fmt.Sprintf("MustOK: no %T value", v))
+	}
+	return v
+}
+
+// IsZeroer is implemented by the types that define their own zero value check, such as time.Time.
+type IsZeroer interface {
+	IsZero() bool
+}
+
+// IsZero returns true if the given value is the zero value for its type, or if it implements IsZeroer and its
+// IsZero method returns true. Comparable values are compared with ==, and the rest with reflect.DeepEqual.
+// Prefer IsZeroT for comparable types, which avoids reflection.
 func IsZero(t any) bool {
-	return t == nil || reflect.DeepEqual(t, reflect.Zero(reflect.TypeOf(t)).Interface())
+	if t == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(t)
+	zero := reflect.Zero(v.Type()).Interface()
+	if !v.Comparable() {
+		return reflect.DeepEqual(t, zero)
+	}
+	if t == zero {
+		return true
+	}
+
+	z, ok := t.(IsZeroer)
+	return ok && z.IsZero()
 }
 
-// StructToMap converts a struct to a map[string]any.
+// IsZeroT returns true if v is the zero value of T, or if it implements IsZeroer and its IsZero method returns true,
+// e.g. a time.Time in another location. Nil pointers are zero without calling their IsZero method.
+func IsZeroT[T comparable](v T) bool {
+	var zero T
+	if v == zero {
+		return true
+	}
+
+	z, ok := any(v).(IsZeroer)
+	return ok && z.IsZero()
+}
+
+// StructToMap converts a struct to a map[string]any through a JSON round trip, so numbers become float64.
+// It is kept for compatibility; StructToMapWithOptions keeps the value types and avoids the round trip.
 func StructToMap(t any) (map[string]any, error) {
 	data, err := json.Marshal(t)
 	if err != nil {
@@ -97,6 +171,27 @@ func DefaultIfNil[T any](a *T, b T) T {
 	return b
 }
 
+// Coalesce returns the first of values that is not zero, as defined by IsZeroT, or the zero value if all are zero.
+func Coalesce[T comparable](values ...T) T {
+	for _, v := range values {
+		if !IsZeroT(v) {
+			return v
+		}
+	}
+	var zero T
+	return zero
+}
+
+// CoalescePtr returns the first of ptrs that is not nil, or nil if all are nil.
+func CoalescePtr[T any](ptrs ...*T) *T {
+	for _, p := range ptrs {
+		if p != nil {
+			return p
+		}
+	}
+	return nil
+}
+
 // ZeroValue returns the zero value of the given type.
 func ZeroValue[T any]() T {
 	var zero T
@@ -105,6 +200,7 @@ func ZeroValue[T any]() T {
 
 // ToInt converts the given value to int.
 // Converts float64, float32, int, int64, int32, int16, int8, uint, uint64, uint32, uint16, uint8 to int.
+// Floats are truncated and out of range values wrap around; use ToNumber to detect them.
 func ToInt(value any) (int, bool) {
 	switch v := value.(type) {
 	case float64:
@@ -168,6 +264,221 @@ func ToFloat64(value any) (float64, bool) {
 	}
 }
 
+// ToNumber converts the given value to the number type T.
+// Converts integer and float types, and strings holding a number (e.g. '42', '-7' or '3.5'), ignoring surrounding
+// spaces. Returns false if the value doesn't fit in T, or if it would lose precision: a float with a fractional part
+// converted to an integer type, or an integer or float64 that has no exact representation as a float.
+// Strings converted to a float type are rounded to the nearest value instead.
+func ToNumber[T Number](value any) (T, bool) {
+	var result T
+	var target = reflect.ValueOf(&result).Elem()
+
+	rv := reflect.ValueOf(value)
+	if !rv.IsValid() {
+		return result, false
+	}
+
+	if rv.Kind() == reflect.String {
+		s := strings.TrimSpace(rv.String())
+		if target.CanFloat() {
+			f, err := strconv.ParseFloat(s, target.Type().Bits())
+			if err != nil {
+				return result, false
+			}
+			target.SetFloat(f)
+			return result, true
+		}
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			rv = reflect.ValueOf(i)
+		} else if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+			rv = reflect.ValueOf(u)
+		} else if f, err := strconv.ParseFloat(s, 64); err == nil {
+			rv = reflect.ValueOf(f)
+		} else {
+			return result, false
+		}
+	}
+
+	switch {
+	case target.CanInt():
+		i, ok := numberToInt64(rv)
+		if !ok || target.OverflowInt(i) {
+			return result, false
+		}
+		target.SetInt(i)
+	case target.CanUint():
+		u, ok := numberToUint64(rv)
+		if !ok || target.OverflowUint(u) {
+			return result, false
+		}
+		target.SetUint(u)
+	default:
+		f, ok := numberToFloat64(rv)
+		if !ok || (target.Kind() == reflect.Float32 && !math.IsNaN(f) && float64(float32(f)) != f) {
+			return result, false
+		}
+		target.SetFloat(f)
+	}
+	return result, true
+}
+
+// numberToInt64 converts the number v to int64, returning false if it doesn't fit or has a fractional part.
+func numberToInt64(v reflect.Value) (int64, bool) {
+	switch {
+	case v.CanInt():
+		return v.Int(), true
+	case v.CanUint():
+		return int64(v.Uint()), v.Uint() <= math.MaxInt64
+	case v.CanFloat():
+		f := v.Float()
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+			return 0, false
+		}
+		return int64(f), true
+	default:
+		return 0, false
+	}
+}
+
+// numberToUint64 converts the number v to uint64, returning false if it doesn't fit or has a fractional part.
+func numberToUint64(v reflect.Value) (uint64, bool) {
+	switch {
+	case v.CanInt():
+		return uint64(v.Int()), v.Int() >= 0
+	case v.CanUint():
+		return v.Uint(), true
+	case v.CanFloat():
+		f := v.Float()
+		if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 {
+			return 0, false
+		}
+		return uint64(f), true
+	default:
+		return 0, false
+	}
+}
+
+// numberToFloat64 converts the number v to float64, returning false if an integer has no exact representation.
+func numberToFloat64(v reflect.Value) (float64, bool) {
+	switch {
+	case v.CanInt():
+		f := float64(v.Int())
+		return f, f < math.MaxInt64 && int64(f) == v.Int()
+	case v.CanUint():
+		f := float64(v.Uint())
+		return f, f < math.MaxUint64 && uint64(f) == v.Uint()
+	case v.CanFloat():
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// ToString converts the given value to string.
+// Converts string, []byte, time.Time (RFC 3339), fmt.Stringer, bool, and integer and float types to string.
+func ToString(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case []byte:
+		return string(v), true
+	case time.Time:
+		return v.Format(time.RFC3339Nano), true
+	case fmt.Stringer:
+		return v.String(), true
+	case bool:
+		return strconv.FormatBool(v), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), true
+	case int, int64, int32, int16, int8, uint, uint64, uint32, uint16, uint8:
+		return fmt.Sprint(v), true
+	default:
+		return "", false
+	}
+}
+
+// ToBool converts the given value to bool.
+// Converts bool, numbers (true if not zero) and strings accepted by strconv.ParseBool or
+// 'yes', 'no', 'y', 'n', 'on' and 'off', ignoring case and surrounding spaces.
+func ToBool(value any) (bool, bool) {
+	switch v := value.(type) {
+	case bool:
+		return v, true
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "yes", "y", "on":
+			return true, true
+		case "no", "n", "off":
+			return false, true
+		}
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		return b, err == nil
+	default:
+		f, ok := ToFloat64(value)
+		return ok && f != 0, ok
+	}
+}
+
+// ToTime converts the given value to time.Time.
+// Converts time.Time, *time.Time, numbers as Unix seconds, and strings parsed with the given layouts, tried in order.
+// If no layouts are given, RFC 3339, '2006-01-02T15:04:05', '2006-01-02 15:04:05', '2006-01-02', RFC 1123Z and
+// RFC 1123 are tried.
+func ToTime(value any, layouts ...string) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case *time.Time:
+		if v == nil {
+			return time.Time{}, false
+		}
+		return *v, true
+	case string:
+		if len(layouts) == 0 {
+			layouts = defaultTimeLayouts
+		}
+		for _, layout := range layouts {
+			if t, err := time.Parse(layout, strings.TrimSpace(v)); err == nil {
+				return t, true
+			}
+		}
+		return time.Time{}, false
+	case float64:
+		sec, frac := math.Modf(v)
+		return time.Unix(int64(sec), int64(frac*1e9)), true
+	case float32:
+		return ToTime(float64(v))
+	default:
+		if i, ok := ToInt(value); ok {
+			return time.Unix(int64(i), 0), true
+		}
+		return time.Time{}, false
+	}
+}
+
+// ToDuration converts the given value to time.Duration.
+// Converts time.Duration, strings accepted by ParseDurationExtended (e.g. '1h30m' or '2d') and
+// numbers as nanoseconds, as time.Duration(n) does.
+func ToDuration(value any) (time.Duration, bool) {
+	switch v := value.(type) {
+	case time.Duration:
+		return v, true
+	case string:
+		d, err := ParseDurationExtended(strings.TrimSpace(v))
+		return d, err == nil
+	case float64:
+		return time.Duration(v), true
+	case float32:
+		return time.Duration(v), true
+	default:
+		if i, ok := ToInt(value); ok {
+			return time.Duration(i), true
+		}
+		return 0, false
+	}
+}
+
 // StrToStruct converts a string to a struct.
 func StrToStruct[T any](s string) (*T, error) {
 	var t = new(T)
@@ -0,0 +1,266 @@
+package devtoolkit
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"iter"
+	"reflect"
+	"slices"
+	"strconv"
+)
+
+// OrderedMap is a generic map that keeps the insertion order of its keys, giving deterministic iteration
+// and JSON/YAML output. Setting an existing key updates its value without changing its position.
+// The zero value is an empty map ready to use. An OrderedMap is not safe for concurrent use.
+type OrderedMap[K comparable, V any] struct {
+	index   map[K]int
+	entries []Pair[K, V]
+}
+
+// NewOrderedMap returns a new OrderedMap containing the given entries, in order.
+func NewOrderedMap[K comparable, V any](entries ...Pair[K, V]) *OrderedMap[K, V] {
+	var m = &OrderedMap[K, V]{index: make(map[K]int, len(entries))}
+	for _, e := range entries {
+		m.Set(e.First, e.Second)
+	}
+	return m
+}
+
+// Get returns the value for key and true, or the zero value and false if key is not present.
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+	if i, ok := m.index[key]; ok {
+		return m.entries[i].Second, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Set sets the value for key. New keys are added to the end of the map.
+func (m *OrderedMap[K, V]) Set(key K, value V) {
+	if i, ok := m.index[key]; ok {
+		m.entries[i].Second = value
+		return
+	}
+	if m.index == nil {
+		m.index = make(map[K]int)
+	}
+	m.index[key] = len(m.entries)
+	m.entries = append(m.entries, NewPair(key, value))
+}
+
+// Delete removes key from the map, keeping the order of the remaining keys.
+// Returns true if key was present.
+func (m *OrderedMap[K, V]) Delete(key K) bool {
+	i, ok := m.index[key]
+	if !ok {
+		return false
+	}
+
+	delete(m.index, key)
+	m.entries = slices.Delete(m.entries, i, i+1)
+	for j := i; j < len(m.entries); j++ {
+		m.index[m.entries[j].First] = j
+	}
+	return true
+}
+
+// Has returns true if key is present in the map.
+func (m *OrderedMap[K, V]) Has(key K) bool {
+	_, ok := m.index[key]
+	return ok
+}
+
+// Len returns the number of keys in the map.
+func (m *OrderedMap[K, V]) Len() int {
+	return len(m.entries)
+}
+
+// Keys returns a new slice containing the keys of the map, in order.
+func (m *OrderedMap[K, V]) Keys() []K {
+	var keys = make([]K, len(m.entries))
+	for i, e := range m.entries {
+		keys[i] = e.First
+	}
+	return keys
+}
+
+// Values returns a new slice containing the values of the map, in key order.
+func (m *OrderedMap[K, V]) Values() []V {
+	var values = make([]V, len(m.entries))
+	for i, e := range m.entries {
+		values[i] = e.Second
+	}
+	return values
+}
+
+// Entries returns a new slice containing the key-value pairs of the map, in order.
+func (m *OrderedMap[K, V]) Entries() []Pair[K, V] {
+	return append([]Pair[K, V](nil), m.entries...)
+}
+
+// All returns a sequence over the keys and values of the map, in order.
+// The map must not be modified during the iteration.
+func (m *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, e := range m.entries {
+			if !yield(e.First, e.Second) {
+				return
+			}
+		}
+	}
+}
+
+// MarshalJSON encodes the map as a JSON object with the keys in order.
+// Keys must be strings, integers or implement encoding.TextMarshaler, as for regular maps.
+func (m OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, e := range m.entries {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := orderedMapKeyToString(e.First)
+		if err != nil {
+			return nil, err
+		}
+		keyArr, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		valueArr, err := json.Marshal(e.Second)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(keyArr)
+		buf.WriteByte(':')
+		buf.Write(valueArr)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes a JSON object into the map, keeping the order of its keys.
+// Existing entries are kept; decoded keys already present are updated in place.
+func (m *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if t, err := dec.Token(); err != nil {
+		return err
+	} else if t != json.Delim('{') {
+		return fmt.Errorf("cannot unmarshal %v into OrderedMap: expected JSON object", t)
+	}
+
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		key, err := orderedMapKeyFromString[K](t.(string))
+		if err != nil {
+			return err
+		}
+
+		var value V
+		if err = dec.Decode(&value); err != nil {
+			return err
+		}
+		m.Set(key, value)
+	}
+
+	_, err := dec.Token()
+	return err
+}
+
+// MarshalYAML encodes the map as a YAML mapping with the keys in order.
+func (m OrderedMap[K, V]) MarshalYAML() (any, error) {
+	var node = &yaml.Node{Kind: yaml.MappingNode}
+	for _, e := range m.entries {
+		var keyNode, valueNode yaml.Node
+		if err := keyNode.Encode(e.First); err != nil {
+			return nil, err
+		}
+		if err := valueNode.Encode(e.Second); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, &keyNode, &valueNode)
+	}
+	return node, nil
+}
+
+// UnmarshalYAML decodes a YAML mapping into the map, keeping the order of its keys.
+// Existing entries are kept; decoded keys already present are updated in place.
+func (m *OrderedMap[K, V]) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("cannot unmarshal YAML node at line %d into OrderedMap: expected mapping", node.Line)
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		var key K
+		if err := node.Content[i].Decode(&key); err != nil {
+			return err
+		}
+		var value V
+		if err := node.Content[i+1].Decode(&value); err != nil {
+			return err
+		}
+		m.Set(key, value)
+	}
+	return nil
+}
+
+// orderedMapKeyToString returns the JSON object key of k, following the rules of encoding/json for map keys.
+func orderedMapKeyToString[K comparable](k K) (string, error) {
+	rv := reflect.ValueOf(k)
+	if rv.Kind() == reflect.String {
+		return rv.String(), nil
+	}
+	if tm, ok := any(k).(encoding.TextMarshaler); ok {
+		b, err := tm.MarshalText()
+		return string(b), err
+	}
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	default:
+		return "", fmt.Errorf("unsupported OrderedMap key type %T for JSON", k)
+	}
+}
+
+// orderedMapKeyFromString parses a JSON object key into a K, following the rules of encoding/json for map keys.
+func orderedMapKeyFromString[K comparable](s string) (K, error) {
+	var k K
+	rv := reflect.ValueOf(&k).Elem()
+	if rv.Kind() == reflect.String {
+		rv.SetString(s)
+		return k, nil
+	}
+	if tu, ok := any(&k).(encoding.TextUnmarshaler); ok {
+		return k, tu.UnmarshalText([]byte(s))
+	}
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, rv.Type().Bits())
+		if err != nil {
+			return k, fmt.Errorf("invalid OrderedMap key '%s': %w", s, err)
+		}
+		rv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 10, rv.Type().Bits())
+		if err != nil {
+			return k, fmt.Errorf("invalid OrderedMap key '%s': %w", s, err)
+		}
+		rv.SetUint(n)
+	default:
+		return k, fmt.Errorf("unsupported OrderedMap key type %T for JSON", k)
+	}
+	return k, nil
+}
@@ -0,0 +1,136 @@
+package devtoolkit
+
+// OrderedSet is a generic set of comparable items that keeps their insertion order,
+// giving deterministic iteration and output. Re-adding an item does not change its position.
+// The zero value is an empty set ready to use. An OrderedSet is not safe for concurrent use.
+type OrderedSet[T comparable] struct {
+	index map[T]int
+	items []T
+}
+
+// NewOrderedSet returns a new OrderedSet containing the given items, in order.
+func NewOrderedSet[T comparable](items ...T) *OrderedSet[T] {
+	var s = &OrderedSet[T]{index: make(map[T]int, len(items))}
+	s.Add(items...)
+	return s
+}
+
+// Add adds the given items to the end of the set, skipping the ones already present.
+func (s *OrderedSet[T]) Add(items ...T) {
+	if s.index == nil {
+		s.index = make(map[T]int, len(items))
+	}
+	for _, item := range items {
+		if _, ok := s.index[item]; ok {
+			continue
+		}
+		s.index[item] = len(s.items)
+		s.items = append(s.items, item)
+	}
+}
+
+// Remove removes the given items from the set, if present, keeping the order of the remaining items.
+func (s *OrderedSet[T]) Remove(items ...T) {
+	var removed bool
+	for _, item := range items {
+		if _, ok := s.index[item]; ok {
+			delete(s.index, item)
+			removed = true
+		}
+	}
+	if !removed {
+		return
+	}
+
+	var kept = s.items[:0]
+	for _, item := range s.items {
+		if _, ok := s.index[item]; ok {
+			s.index[item] = len(kept)
+			kept = append(kept, item)
+		}
+	}
+	clear(s.items[len(kept):])
+	s.items = kept
+}
+
+// Contains returns true if item is in the set.
+func (s *OrderedSet[T]) Contains(item T) bool {
+	_, ok := s.index[item]
+	return ok
+}
+
+// Len returns the number of items in the set.
+func (s *OrderedSet[T]) Len() int {
+	return len(s.items)
+}
+
+// ToSlice returns the items of the set in insertion order.
+func (s *OrderedSet[T]) ToSlice() []T {
+	var items = make([]T, len(s.items))
+	copy(items, s.items)
+	return items
+}
+
+// Clone returns a new set with the same items in the same order.
+func (s *OrderedSet[T]) Clone() *OrderedSet[T] {
+	return NewOrderedSet(s.items...)
+}
+
+// Union returns a new set with the items of s followed by the items of other that are not in s.
+func (s *OrderedSet[T]) Union(other *OrderedSet[T]) *OrderedSet[T] {
+	var u = s.Clone()
+	u.Add(other.items...)
+	return u
+}
+
+// Intersection returns a new set with the items of s that are in other, in the order of s.
+func (s *OrderedSet[T]) Intersection(other *OrderedSet[T]) *OrderedSet[T] {
+	var i = NewOrderedSet[T]()
+	for _, item := range s.items {
+		if other.Contains(item) {
+			i.Add(item)
+		}
+	}
+	return i
+}
+
+// Difference returns a new set with the items of s that are not in other, in the order of s.
+func (s *OrderedSet[T]) Difference(other *OrderedSet[T]) *OrderedSet[T] {
+	var d = NewOrderedSet[T]()
+	for _, item := range s.items {
+		if !other.Contains(item) {
+			d.Add(item)
+		}
+	}
+	return d
+}
+
+// SymmetricDifference returns a new set with the items of s that are not in other,
+// followed by the items of other that are not in s.
+func (s *OrderedSet[T]) SymmetricDifference(other *OrderedSet[T]) *OrderedSet[T] {
+	var d = s.Difference(other)
+	for _, item := range other.items {
+		if !s.Contains(item) {
+			d.Add(item)
+		}
+	}
+	return d
+}
+
+// IsSubsetOf returns true if all the items of s are in other, regardless of order.
+func (s *OrderedSet[T]) IsSubsetOf(other *OrderedSet[T]) bool {
+	if s.Len() > other.Len() {
+		return false
+	}
+	for _, item := range s.items {
+		if !other.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal returns true if s and other contain the same items, regardless of order.
+func (s *OrderedSet[T]) Equal(other *OrderedSet[T]) bool {
+	return s.Len() == other.Len() && s.IsSubsetOf(other)
+}
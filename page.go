@@ -0,0 +1,57 @@
+package devtoolkit
+
+// Page is a page of items of a paginated slice.
+type Page[T any] struct {
+	// Items are the items of the page, sharing the backing array of the paginated slice.
+	Items []T `json:"items"`
+
+	// Page is the 1-based page number.
+	Page int `json:"page"`
+
+	// PageSize is the maximum number of items per page.
+	PageSize int `json:"pageSize"`
+
+	// TotalItems is the number of items of the paginated slice.
+	TotalItems int `json:"totalItems"`
+
+	// TotalPages is the number of pages of the paginated slice.
+	TotalPages int `json:"totalPages"`
+
+	// HasNext is true if there is a page after this one.
+	HasNext bool `json:"hasNext"`
+
+	// HasPrev is true if there is a page before this one.
+	HasPrev bool `json:"hasPrev"`
+}
+
+// Paginate returns the given 1-based page of slice, with up to pageSize items.
+// A page lower than 1 is treated as the first page and a pageSize lower than 1 as a single page with all the items.
+// Pages beyond the last one have no items.
+func Paginate[T any](slice []T, page, pageSize int) Page[T] {
+	var total = len(slice)
+	if pageSize < 1 {
+		pageSize = max(total, 1)
+	}
+	page = max(page, 1)
+
+	// page and pageSize may come from clients, so avoid overflowing with huge values
+	var totalPages = total / pageSize
+	if total%pageSize != 0 {
+		totalPages++
+	}
+	var start = total
+	if page <= totalPages {
+		start = (page - 1) * pageSize
+	}
+	var end = start + min(pageSize, total-start)
+
+	return Page[T]{
+		Items:      slice[start:end:end],
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: total,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+	}
+}
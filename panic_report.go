@@ -0,0 +1,181 @@
+package devtoolkit
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const panicRedacted = "[REDACTED]"
+
+type panicMetadataCtxKey struct{}
+
+var (
+	panicRedactionMu sync.RWMutex
+
+	// panicRedactPatterns are the patterns redacted in the panic message and stack.
+	panicRedactPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)((?:password|passwd|secret|token|authorization|api[-_]?key|private[-_]?key|credential)\s*[=:]\s*)("[^"]*"|\S+)`),
+	}
+)
+
+// PanicReport is a recovered panic with the information operators need to act on it: the panic value, the stack,
+// the goroutine ID and the metadata attached to the context. Secrets are redacted from the message, the stack and
+// the metadata (see RegisterPanicRedaction and RegisterSecretKeyMarker).
+// It implements error, so it can be returned by recovery wrappers and retrieved with errors.As.
+type PanicReport struct {
+	Value       any               // the recovered value
+	Message     string            // the redacted recovered value as text
+	GoroutineID int64             // ID of the goroutine that panicked, 0 if unknown
+	Stack       string            // redacted stack trace of the goroutine that panicked
+	Time        time.Time         // when the report was created
+	Metadata    map[string]string // redacted metadata attached to the context with WithPanicMetadata
+}
+
+// NewPanicReport returns a report of the recovered value, including the metadata attached to ctx.
+// It must be called from the deferred function that recovered the panic, so the stack includes the panicking frames.
+func NewPanicReport(ctx context.Context, recovered any) *PanicReport {
+	stack := goroutineStack()
+
+	panicRedactionMu.RLock()
+	defer panicRedactionMu.RUnlock()
+
+	var metadata = make(map[string]string)
+	if ctx != nil {
+		if md, ok := ctx.Value(panicMetadataCtxKey{}).(map[string]string); ok {
+			for k, v := range md {
+				metadata[k] = redactPanicMetadata(k, v)
+			}
+		}
+	}
+
+	return &PanicReport{
+		Value:       recovered,
+		Message:     redactPanicText(fmt.Sprint(recovered)),
+		GoroutineID: parseGoroutineID(stack),
+		Stack:       redactPanicText(string(stack)),
+		Time:        time.Now(),
+		Metadata:    metadata,
+	}
+}
+
+// Error returns the redacted panic message.
+func (r *PanicReport) Error() string {
+	return "panic: " + r.Message
+}
+
+// String returns the full report, ready to be logged.
+func (r *PanicReport) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "panic: %s\n", r.Message)
+	fmt.Fprintf(&sb, "time: %s\n", r.Time.Format(time.RFC3339Nano))
+	fmt.Fprintf(&sb, "goroutine: %d\n", r.GoroutineID)
+
+	if len(r.Metadata) > 0 {
+		sb.WriteString("metadata:\n")
+		var keys = MapKeys(r.Metadata)
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(&sb, "  %s: %s\n", k, r.Metadata[k])
+		}
+	}
+
+	sb.WriteString("stack:\n")
+	sb.WriteString(r.Stack)
+	return sb.String()
+}
+
+// WithPanicMetadata returns a copy of ctx with a metadata entry included in the panic reports created with it,
+// e.g. a request ID or the name of the job being processed.
+func WithPanicMetadata(ctx context.Context, key, value string) context.Context {
+	var md = make(map[string]string)
+	if parent, ok := ctx.Value(panicMetadataCtxKey{}).(map[string]string); ok {
+		for k, v := range parent {
+			md[k] = v
+		}
+	}
+	md[key] = value
+	return context.WithValue(ctx, panicMetadataCtxKey{}, md)
+}
+
+// RegisterPanicRedaction adds metadata key substrings (case-insensitive) whose values are redacted from panic reports,
+// and patterns redacted from their messages and stacks. When a pattern has capture groups, only the last group is
+// redacted, so e.g. `(token=)(\S+)` keeps the key visible.
+// The keys are registered with RegisterSecretKeyMarker, so they are masked by DiffPropFiles too.
+// By default, metadata keys containing the secret key markers (password, secret, token, authorization, api key,
+// private key, credential) are redacted, as well as 'key=value' and 'key: value' occurrences of them in the message
+// and stack.
+func RegisterPanicRedaction(sensitiveKeys []string, patterns ...*regexp.Regexp) {
+	RegisterSecretKeyMarker(sensitiveKeys...)
+
+	panicRedactionMu.Lock()
+	defer panicRedactionMu.Unlock()
+	panicRedactPatterns = append(panicRedactPatterns, patterns...)
+}
+
+// redactPanicMetadata returns the redacted value of a metadata entry. Must be called with panicRedactionMu held.
+func redactPanicMetadata(key, value string) string {
+	if isSecretKey(key) {
+		return panicRedacted
+	}
+	return redactPanicText(value)
+}
+
+// redactPanicText redacts the registered patterns from s. Must be called with panicRedactionMu held.
+func redactPanicText(s string) string {
+	for _, p := range panicRedactPatterns {
+		s = p.ReplaceAllStringFunc(s, func(match string) string {
+			sub := p.FindStringSubmatchIndex(match)
+			if len(sub) <= 2 {
+				return panicRedacted
+			}
+			// redact the last capture group only.
+			start, end := sub[len(sub)-2], sub[len(sub)-1]
+			if start < 0 {
+				return match
+			}
+			return match[:start] + panicRedacted + match[end:]
+		})
+	}
+	return s
+}
+
+// callWithPanicReport calls fn and returns its error, or a *PanicReport of the metadata of ctx if fn panics.
+func callWithPanicReport(ctx context.Context, fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = NewPanicReport(ctx, r)
+		}
+	}()
+	return fn()
+}
+
+// goroutineStack returns the stack trace of the calling goroutine.
+func goroutineStack() []byte {
+	var buf = make([]byte, 4096)
+	for {
+		n := runtime.Stack(buf, false)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// parseGoroutineID extracts the goroutine ID from the first line of a stack trace ("goroutine 42 [running]:").
+func parseGoroutineID(stack []byte) int64 {
+	line, _, _ := bytes.Cut(stack, []byte("\n"))
+	fields := bytes.Fields(line)
+	if len(fields) < 2 || string(fields[0]) != "goroutine" {
+		return 0
+	}
+	id, _ := strconv.ParseInt(string(fields[1]), 10, 64)
+	return id
+}
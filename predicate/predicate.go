@@ -0,0 +1,117 @@
+// Package predicate provides composable predicates (func(T) bool): And/Or/Not combinators and builders for common
+// conditions, usable with devtoolkit.Filter, devtoolkit.RemoveIf, seq.Filter or the CSV reader CountWhere and Exists
+// methods without writing inline closures.
+package predicate
+
+import (
+	"golang.org/x/exp/constraints"
+	"regexp"
+	"strings"
+)
+
+// And returns a predicate that is true if all the given predicates are true, evaluated in order until one is false.
+// It is true if no predicates are given.
+func And[T any](predicates ...func(T) bool) func(T) bool {
+	return func(v T) bool {
+		for _, p := range predicates {
+			if !p(v) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or returns a predicate that is true if any of the given predicates is true, evaluated in order until one is true.
+// It is false if no predicates are given.
+func Or[T any](predicates ...func(T) bool) func(T) bool {
+	return func(v T) bool {
+		for _, p := range predicates {
+			if p(v) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not returns a predicate that negates the given one.
+func Not[T any](predicate func(T) bool) func(T) bool {
+	return func(v T) bool {
+		return !predicate(v)
+	}
+}
+
+// EqualTo returns a predicate that is true for values equal to value.
+func EqualTo[T comparable](value T) func(T) bool {
+	return func(v T) bool {
+		return v == value
+	}
+}
+
+// In returns a predicate that is true for values equal to any of the given values.
+func In[T comparable](values ...T) func(T) bool {
+	var set = make(map[T]struct{}, len(values))
+	for _, v := range values {
+		set[v] = struct{}{}
+	}
+	return func(v T) bool {
+		_, ok := set[v]
+		return ok
+	}
+}
+
+// Between returns a predicate that is true for values in the inclusive range [min, max].
+func Between[T constraints.Ordered](min, max T) func(T) bool {
+	return func(v T) bool {
+		return v >= min && v <= max
+	}
+}
+
+// GreaterThan returns a predicate that is true for values greater than value.
+func GreaterThan[T constraints.Ordered](value T) func(T) bool {
+	return func(v T) bool {
+		return v > value
+	}
+}
+
+// LessThan returns a predicate that is true for values less than value.
+func LessThan[T constraints.Ordered](value T) func(T) bool {
+	return func(v T) bool {
+		return v < value
+	}
+}
+
+// MatchesRegex returns a predicate that is true for strings matching the regular expression re.
+func MatchesRegex(re *regexp.Regexp) func(string) bool {
+	return re.MatchString
+}
+
+// HasPrefix returns a predicate that is true for strings starting with prefix.
+func HasPrefix(prefix string) func(string) bool {
+	return func(s string) bool {
+		return strings.HasPrefix(s, prefix)
+	}
+}
+
+// Contains returns a predicate that is true for strings containing substr.
+func Contains(substr string) func(string) bool {
+	return func(s string) bool {
+		return strings.Contains(s, substr)
+	}
+}
+
+// Blank returns a predicate that is true for strings that are empty or contain only white space.
+func Blank() func(string) bool {
+	return func(s string) bool {
+		return strings.TrimSpace(s) == ""
+	}
+}
+
+// By returns a predicate on T that applies predicate to the key returned by keyFn,
+// e.g. By(func(u User) int { return u.Age }, Between(18, 65)).
+func By[T, K any](keyFn func(T) K, predicate func(K) bool) func(T) bool {
+	return func(v T) bool {
+		return predicate(keyFn(v))
+	}
+}
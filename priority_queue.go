@@ -0,0 +1,93 @@
+package devtoolkit
+
+import "container/heap"
+
+// PriorityQueue is a generic priority queue built on container/heap,
+// popping first the item that is less than all the others according to the less function.
+// A PriorityQueue is not safe for concurrent use.
+type PriorityQueue[T any] struct {
+	h *priorityHeap[T]
+}
+
+// NewPriorityQueue returns a new PriorityQueue ordered by less, containing the given items.
+func NewPriorityQueue[T any](less func(a, b T) bool, items ...T) *PriorityQueue[T] {
+	var h = &priorityHeap[T]{less: less, items: append([]T(nil), items...)}
+	heap.Init(h)
+	return &PriorityQueue[T]{h: h}
+}
+
+// Push adds item to the queue.
+func (pq *PriorityQueue[T]) Push(item T) {
+	heap.Push(pq.h, item)
+}
+
+// PushAll adds all the given items to the queue.
+func (pq *PriorityQueue[T]) PushAll(items ...T) {
+	// re-heapifying is cheaper than pushing one by one when adding many items
+	if len(items) > pq.Len() {
+		pq.h.items = append(pq.h.items, items...)
+		heap.Init(pq.h)
+		return
+	}
+	for _, item := range items {
+		heap.Push(pq.h, item)
+	}
+}
+
+// Pop removes and returns the item with the highest priority.
+// Returns false if the queue is empty.
+func (pq *PriorityQueue[T]) Pop() (T, bool) {
+	if pq.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+	return heap.Pop(pq.h).(T), true
+}
+
+// Peek returns the item with the highest priority without removing it.
+// Returns false if the queue is empty.
+func (pq *PriorityQueue[T]) Peek() (T, bool) {
+	if pq.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+	return pq.h.items[0], true
+}
+
+// Drain removes all the items of the queue and returns them in priority order.
+func (pq *PriorityQueue[T]) Drain() []T {
+	var items = make([]T, 0, pq.Len())
+	for pq.Len() > 0 {
+		items = append(items, heap.Pop(pq.h).(T))
+	}
+	return items
+}
+
+// Len returns the number of items in the queue.
+func (pq *PriorityQueue[T]) Len() int {
+	return pq.h.Len()
+}
+
+// IsEmpty returns true if the queue has no items.
+func (pq *PriorityQueue[T]) IsEmpty() bool {
+	return pq.h.Len() == 0
+}
+
+// priorityHeap implements heap.Interface for PriorityQueue.
+type priorityHeap[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+func (h *priorityHeap[T]) Len() int           { return len(h.items) }
+func (h *priorityHeap[T]) Less(i, j int) bool { return h.less(h.items[i], h.items[j]) }
+func (h *priorityHeap[T]) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *priorityHeap[T]) Push(x any)         { h.items = append(h.items, x.(T)) }
+func (h *priorityHeap[T]) Pop() any {
+	var zero T
+	last := len(h.items) - 1
+	item := h.items[last]
+	h.items[last] = zero
+	h.items = h.items[:last]
+	return item
+}
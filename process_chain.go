@@ -64,11 +64,30 @@ func setProcessChainOptionsDefaults(opts *ProcessChainOptions) *ProcessChainOpti
 // NewProcessChain creates and returns a new instance of a process chain for data of type T.
 func NewProcessChain[T any](opts *ProcessChainOptions) ProcessChain[T] {
 	opts = setProcessChainOptionsDefaults(opts)
+	return NewProcessChainWithOptions[T](func(o *ProcessChainOptions) {
+		*o = *opts
+	})
+}
+
+// NewProcessChainWithOptions creates and returns a new instance of a process chain for data of type T,
+// with the defaults modified by the given option functions, e.g. NewProcessChainWithOptions[T](WithLinkNameInErrors()).
+func NewProcessChainWithOptions[T any](optFns ...func(*ProcessChainOptions)) ProcessChain[T] {
+	var opts = setProcessChainOptionsDefaults(nil)
+	for _, fn := range optFns {
+		fn(opts)
+	}
 	return &processChain[T]{
 		addLinkNameToError: opts.AddLinkNameToError,
 	}
 }
 
+// WithLinkNameInErrors enables ProcessChainOptions.AddLinkNameToError.
+func WithLinkNameInErrors() func(*ProcessChainOptions) {
+	return func(o *ProcessChainOptions) {
+		o.AddLinkNameToError = true
+	}
+}
+
 type LinkInfo[T any] struct {
 	Name       string
 	Step       LinkFn[T]
@@ -0,0 +1,148 @@
+package devtoolkit
+
+import (
+	"encoding/json"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"sort"
+)
+
+// MaskedValue is the value shown instead of secrets in a PropDiff.
+const MaskedValue = "******"
+
+// PropDiffEntry describes a single property that differs between two prop files.
+// Key is the flattened path of the property (e.g. 'db.hosts[0].port').
+type PropDiffEntry struct {
+	Key      string
+	OldValue any
+	NewValue any
+}
+
+// PropDiff holds the differences between two prop files.
+type PropDiff struct {
+	Added   []PropDiffEntry // properties present only in the second file
+	Removed []PropDiffEntry // properties present only in the first file
+	Changed []PropDiffEntry // properties present in both files with different values
+}
+
+// HasChanges returns true if there is any difference.
+func (d *PropDiff) HasChanges() bool {
+	return len(d.Added) > 0 || len(d.Removed) > 0 || len(d.Changed) > 0
+}
+
+// DiffPropFiles loads two prop files (YAML or JSON, with environment variables expanded) and returns
+// the added, removed and changed properties, keyed by their flattened path and sorted by key.
+// Values of properties whose key looks like a secret (password, token, ...) are replaced by MaskedValue.
+func DiffPropFiles(a, b string) (*PropDiff, error) {
+	propsA, err := loadFlattenedPropFile(a)
+	if err != nil {
+		return nil, err
+	}
+
+	propsB, err := loadFlattenedPropFile(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var diff = &PropDiff{}
+	for _, key := range sortedKeys(propsA) {
+		oldValue := propsA[key]
+		newValue, ok := propsB[key]
+		if !ok {
+			diff.Removed = append(diff.Removed, newPropDiffEntry(key, oldValue, nil))
+			continue
+		}
+
+		if fmt.Sprint(oldValue) != fmt.Sprint(newValue) {
+			diff.Changed = append(diff.Changed, newPropDiffEntry(key, oldValue, newValue))
+		}
+	}
+
+	for _, key := range sortedKeys(propsB) {
+		if _, ok := propsA[key]; !ok {
+			diff.Added = append(diff.Added, newPropDiffEntry(key, nil, propsB[key]))
+		}
+	}
+
+	return diff, nil
+}
+
+func newPropDiffEntry(key string, oldValue, newValue any) PropDiffEntry {
+	if isSecretKey(key) {
+		if oldValue != nil {
+			oldValue = MaskedValue
+		}
+		if newValue != nil {
+			newValue = MaskedValue
+		}
+	}
+	return PropDiffEntry{Key: key, OldValue: oldValue, NewValue: newValue}
+}
+
+// loadFlattenedPropFile reads a prop file into a generic document and flattens it.
+func loadFlattenedPropFile(filePath string) (map[string]any, error) {
+	fileType, err := getConfigFileType(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error getting config file type of file '%s': %w", filePath, err)
+	}
+
+	propArr, err := readPropFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading property file '%s': %w", filePath, err)
+	}
+
+	var doc any
+	switch fileType {
+	case ymlType:
+		err = yaml.Unmarshal(propArr, &doc)
+	case jsonType:
+		err = json.Unmarshal(propArr, &doc)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing property file '%s': %w", filePath, err)
+	}
+
+	var flat = make(map[string]any)
+	flattenProp("", doc, flat)
+	return flat, nil
+}
+
+// flattenProp flattens nested maps and slices into dotted keys, using [i] for slice indexes.
+func flattenProp(prefix string, value any, out map[string]any) {
+	switch v := value.(type) {
+	case map[string]any:
+		if len(v) == 0 && prefix != "" {
+			out[prefix] = v
+		}
+		for k, child := range v {
+			flattenProp(joinPropKey(prefix, k), child, out)
+		}
+	case []any:
+		if len(v) == 0 && prefix != "" {
+			out[prefix] = v
+		}
+		for i, child := range v {
+			flattenProp(fmt.Sprintf("%s[%d]", prefix, i), child, out)
+		}
+	default:
+		if prefix != "" {
+			out[prefix] = v
+		}
+	}
+}
+
+func joinPropKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	var keys = make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -7,9 +7,13 @@ import (
 	"github.com/go-playground/validator/v10"
 	"gopkg.in/yaml.v3"
 	"log"
+	"net/url"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // configFileType represents the supported configuration file formats.
@@ -22,6 +26,12 @@ const (
 
 var validatorCustomFuncs = map[string]func(fl validator.FieldLevel) bool{
 	"trimmed-non-empty": trimmedNonEmpty,
+	"duration-min":      durationMin,
+	"duration-max":      durationMax,
+	"oneof-ci":          oneOfCaseInsensitive,
+	"filepath-exists":   filePathExists,
+	"url-http":          httpURL,
+	"port":              port,
 }
 
 // ToolKitProp is an interface that must be implemented by all configuration property structs.
@@ -49,6 +59,18 @@ func LoadPropFile(filePath string, props []ToolKitProp) error {
 		return fmt.Errorf("error reading property file '%s': %w", filePath, err)
 	}
 
+	return loadProps(propArr, fileType, props)
+}
+
+// loadProps migrates and parses the contents of a configuration file of the given type
+// into each of the props, validating them, setting their defaults and running their post load hooks.
+func loadProps(propArr []byte, fileType configFileType, props []ToolKitProp) error {
+	// apply the registered format migrations.
+	propArr, err := migratePropArr(propArr, fileType)
+	if err != nil {
+		return fmt.Errorf("error migrating properties: %w", err)
+	}
+
 	// select the appropriate parsing function based on the file type.
 	var parseFn func([]byte, interface{}) error
 	switch fileType {
@@ -57,7 +79,7 @@ func LoadPropFile(filePath string, props []ToolKitProp) error {
 	case jsonType:
 		parseFn = parseFromJson
 	default:
-		return fmt.Errorf("invalid config file '%s' type. only 'yml' and 'json' are supported", filePath)
+		return errors.New("invalid config type. only 'yml' and 'json' are supported")
 	}
 
 	// parse the configuration file and validate the properties.
@@ -74,23 +96,33 @@ func LoadPropFile(filePath string, props []ToolKitProp) error {
 			continue
 		}
 
-		// validate
-		if err := validate.Struct(prop); err != nil {
+		// validate, set default and post load
+		if err := initProp(validate, prop); err != nil {
 			if parseErr == nil {
 				parseErr = err
 			} else {
 				parseErr = errors.Join(parseErr, err)
 			}
-			continue
 		}
-
-		// set default
-		prop.SetDefaults()
 	}
 
 	return parseErr
 }
 
+// initProp validates a parsed prop, sets its defaults and runs its post load hooks.
+func initProp(validate *validator.Validate, prop ToolKitProp) error {
+	// validate
+	if err := validate.Struct(prop); err != nil {
+		return err
+	}
+
+	// set default
+	prop.SetDefaults()
+
+	// post load
+	return runPostLoad(prop)
+}
+
 // readPropFile reads a file from the provided 'filePath' and returns its contents
 // as a byte slice. Environment variables within the file are expanded.
 // Returns an error if the file does not exist or cannot be read.
@@ -167,3 +199,89 @@ func trimmedNonEmpty(fl validator.FieldLevel) bool {
 	trimmed := strings.TrimSpace(s)
 	return len(trimmed) > 0
 }
+
+// durationMin validates that a duration is greater than or equal to the duration given as param (e.g. 'duration-min=1s').
+// The field can be a time.Duration or a string accepted by ParseDurationExtended.
+func durationMin(fl validator.FieldLevel) bool {
+	d, limit, ok := durationFieldAndParam(fl)
+	return ok && d >= limit
+}
+
+// durationMax validates that a duration is less than or equal to the duration given as param (e.g. 'duration-max=1h').
+// The field can be a time.Duration or a string accepted by ParseDurationExtended.
+func durationMax(fl validator.FieldLevel) bool {
+	d, limit, ok := durationFieldAndParam(fl)
+	return ok && d <= limit
+}
+
+// durationFieldAndParam returns the duration of the field and the one given as param.
+// It panics if the param is not a valid duration, as validator does for the invalid params of its own tags.
+func durationFieldAndParam(fl validator.FieldLevel) (time.Duration, time.Duration, bool) {
+	limit, err := ParseDurationExtended(fl.Param())
+	if err != nil {
+		panic(fmt.Sprintf("invalid param '%s' for validator '%s': %v", fl.Param(), fl.GetTag(), err))
+	}
+
+	field := fl.Field()
+	switch field.Kind() {
+	case reflect.String:
+		d, err := ParseDurationExtended(field.String())
+		return d, limit, err == nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return time.Duration(field.Int()), limit, true
+	default:
+		return 0, 0, false
+	}
+}
+
+// oneOfCaseInsensitive validates that a string is one of the space-separated values given as param,
+// ignoring case (e.g. 'oneof-ci=debug info warn error').
+func oneOfCaseInsensitive(fl validator.FieldLevel) bool {
+	s := fl.Field().String()
+	for _, option := range strings.Fields(fl.Param()) {
+		if strings.EqualFold(s, option) {
+			return true
+		}
+	}
+	return false
+}
+
+// filePathExists validates that a string is the path of an existing file or directory.
+func filePathExists(fl validator.FieldLevel) bool {
+	s := fl.Field().String()
+	if s == "" {
+		return false
+	}
+	_, err := os.Stat(s)
+	return err == nil
+}
+
+// httpURL validates that a string is an absolute URL with 'http' or 'https' scheme and a host.
+func httpURL(fl validator.FieldLevel) bool {
+	u, err := url.Parse(fl.Field().String())
+	if err != nil {
+		return false
+	}
+	scheme := strings.ToLower(u.Scheme)
+	return (scheme == "http" || scheme == "https") && u.Host != ""
+}
+
+// port validates that a number, or a string containing a number, is a valid TCP/UDP port (1-65535).
+func port(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	var p int64
+	switch field.Kind() {
+	case reflect.String:
+		var err error
+		if p, err = strconv.ParseInt(field.String(), 10, 64); err != nil {
+			return false
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		p = field.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		p = int64(min(field.Uint(), 1<<16))
+	default:
+		return false
+	}
+	return p >= 1 && p <= 65535
+}
@@ -0,0 +1,56 @@
+package devtoolkit
+
+import (
+	"sort"
+	"sync"
+)
+
+// PostLoader is an optional interface for prop structs that need to run code after being loaded,
+// e.g. to compute derived fields or normalize values.
+// PostLoad is invoked by LoadPropFile after parsing, validation and SetDefaults.
+type PostLoader interface {
+	PostLoad() error
+}
+
+// PostLoadHook is a function invoked by LoadPropFile for every loaded prop, after its PostLoad method.
+type PostLoadHook func(prop ToolKitProp) error
+
+type postLoadHookEntry struct {
+	order int
+	hook  PostLoadHook
+}
+
+var (
+	postLoadHooksMu sync.RWMutex
+	postLoadHooks   []postLoadHookEntry
+)
+
+// RegisterPostLoadHook registers a hook invoked for every prop loaded by LoadPropFile.
+// Hooks run in ascending order; hooks with the same order run in registration order.
+func RegisterPostLoadHook(order int, hook PostLoadHook) {
+	postLoadHooksMu.Lock()
+	defer postLoadHooksMu.Unlock()
+	postLoadHooks = append(postLoadHooks, postLoadHookEntry{order: order, hook: hook})
+	sort.SliceStable(postLoadHooks, func(i, j int) bool {
+		return postLoadHooks[i].order < postLoadHooks[j].order
+	})
+}
+
+// runPostLoad invokes the PostLoad method of prop, if implemented, and then the registered hooks.
+// It stops at the first error.
+func runPostLoad(prop ToolKitProp) error {
+	if p, ok := prop.(PostLoader); ok {
+		if err := p.PostLoad(); err != nil {
+			return err
+		}
+	}
+
+	postLoadHooksMu.RLock()
+	defer postLoadHooksMu.RUnlock()
+	for _, entry := range postLoadHooks {
+		if err := entry.hook(prop); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package devtoolkit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"strconv"
+	"sync"
+)
+
+// PropVersionKey is the top-level key holding the format version of a prop file.
+// A file without it is considered version 1.
+const PropVersionKey = "version"
+
+// ErrInvalidPropVersion is returned when the version of a prop file is not a positive integer.
+var ErrInvalidPropVersion = errors.New("invalid prop file version")
+
+// PropMigrationFn transforms the raw document of a prop file from one version to the next one.
+// The raw document can be modified in place.
+type PropMigrationFn func(raw map[string]any) error
+
+var (
+	propMigrationsMu sync.RWMutex
+	propMigrations   = make(map[int]PropMigrationFn)
+)
+
+// RegisterPropMigration registers the migration of prop files from version 'from' to version 'from+1'.
+// Migrations are applied in sequence by LoadPropFile and LoadPropSection before unmarshaling,
+// so files written for older formats keep loading after a format change.
+func RegisterPropMigration(from int, fn PropMigrationFn) {
+	propMigrationsMu.Lock()
+	defer propMigrationsMu.Unlock()
+	propMigrations[from] = fn
+}
+
+// migratePropArr applies the registered migrations to the prop file contents and returns the migrated contents,
+// encoded in the same format. The contents are returned unchanged if no migration applies.
+func migratePropArr(propArr []byte, fileType configFileType) ([]byte, error) {
+	propMigrationsMu.RLock()
+	defer propMigrationsMu.RUnlock()
+	if len(propMigrations) == 0 {
+		return propArr, nil
+	}
+
+	var raw map[string]any
+	var err error
+	switch fileType {
+	case ymlType:
+		err = yaml.Unmarshal(propArr, &raw)
+	case jsonType:
+		err = json.Unmarshal(propArr, &raw)
+	}
+	if err != nil || raw == nil {
+		// let the regular parsing report the error
+		return propArr, nil
+	}
+
+	version, err := propVersion(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var migrated bool
+	for {
+		fn, ok := propMigrations[version]
+		if !ok {
+			break
+		}
+		if err := fn(raw); err != nil {
+			return nil, fmt.Errorf("error migrating prop file from version %d to %d: %w", version, version+1, err)
+		}
+		version++
+		raw[PropVersionKey] = version
+		migrated = true
+	}
+
+	if !migrated {
+		return propArr, nil
+	}
+
+	switch fileType {
+	case ymlType:
+		return yaml.Marshal(raw)
+	default:
+		return json.Marshal(raw)
+	}
+}
+
+// propVersion returns the version of the raw document, 1 if it has none.
+func propVersion(raw map[string]any) (int, error) {
+	value, ok := raw[PropVersionKey]
+	if !ok {
+		return 1, nil
+	}
+
+	var version int
+	switch v := value.(type) {
+	case int:
+		version = v
+	case float64:
+		version = int(v)
+		if float64(version) != v {
+			version = 0
+		}
+	case string:
+		version, _ = strconv.Atoi(v)
+	}
+
+	if version < 1 {
+		return 0, fmt.Errorf("%w: '%v'", ErrInvalidPropVersion, value)
+	}
+	return version, nil
+}
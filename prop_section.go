@@ -0,0 +1,95 @@
+package devtoolkit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"strings"
+)
+
+// ErrPropSectionNotFound is returned by LoadPropSection when the key path does not exist in the file.
+var ErrPropSectionNotFound = errors.New("prop section not found")
+
+// LoadPropSection loads the sub-tree of a configuration file (YAML or JSON) found at the dot-separated
+// 'keyPath' (e.g. "generators.struct-guard") into 'prop', so a tool embedded in a larger config file
+// doesn't need wrapper structs for the enclosing sections.
+// Environment variables are expanded, and the prop is validated, defaulted and post loaded as in LoadPropFile.
+// Returns an error wrapping ErrPropSectionNotFound if the key path does not exist.
+func LoadPropSection(filePath, keyPath string, prop ToolKitProp) error {
+	fileType, err := getConfigFileType(filePath)
+	if err != nil {
+		return fmt.Errorf("error getting config file type of file '%s': %w", filePath, err)
+	}
+
+	propArr, err := readPropFile(filePath)
+	if err != nil {
+		return fmt.Errorf("error reading property file '%s': %w", filePath, err)
+	}
+
+	propArr, err = migratePropArr(propArr, fileType)
+	if err != nil {
+		return fmt.Errorf("error migrating property file '%s': %w", filePath, err)
+	}
+
+	var keys = strings.Split(keyPath, ".")
+	switch fileType {
+	case ymlType:
+		err = parseYmlSection(propArr, keys, prop)
+	case jsonType:
+		err = parseJsonSection(propArr, keys, prop)
+	}
+	if err != nil {
+		return fmt.Errorf("error loading section '%s' of property file '%s': %w", keyPath, filePath, err)
+	}
+
+	return initProp(newValidator(), prop)
+}
+
+// parseYmlSection decodes the YAML node found at the given keys into prop.
+func parseYmlSection(propArr []byte, keys []string, prop any) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(propArr, &doc); err != nil {
+		return err
+	}
+
+	var node = &doc
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	for _, key := range keys {
+		var child *yaml.Node
+		if node.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == key {
+					child = node.Content[i+1]
+				}
+			}
+		}
+		if child == nil {
+			return fmt.Errorf("%w: key '%s'", ErrPropSectionNotFound, key)
+		}
+		node = child
+	}
+
+	return node.Decode(prop)
+}
+
+// parseJsonSection decodes the JSON value found at the given keys into prop.
+func parseJsonSection(propArr []byte, keys []string, prop any) error {
+	var raw = json.RawMessage(propArr)
+	for _, key := range keys {
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			return fmt.Errorf("%w: key '%s'", ErrPropSectionNotFound, key)
+		}
+		child, ok := obj[key]
+		if !ok {
+			return fmt.Errorf("%w: key '%s'", ErrPropSectionNotFound, key)
+		}
+		raw = child
+	}
+
+	return json.Unmarshal(raw, prop)
+}
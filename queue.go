@@ -0,0 +1,52 @@
+package devtoolkit
+
+// Queue is a generic FIFO container backed by a ring buffer, with an optional bounded capacity.
+// The zero value is an empty unbounded queue ready to use. A Queue is not safe for concurrent use.
+type Queue[T any] struct {
+	items    Deque[T]
+	capacity int
+}
+
+// NewQueue returns a new Queue holding at most 'capacity' items, unbounded if capacity is not positive.
+func NewQueue[T any](capacity int) *Queue[T] {
+	var q = &Queue[T]{capacity: max(capacity, 0)}
+	q.items.items = make([]T, q.capacity)
+	return q
+}
+
+// Enqueue adds item to the back of the queue.
+// Returns false if the queue is full.
+func (q *Queue[T]) Enqueue(item T) bool {
+	if q.IsFull() {
+		return false
+	}
+	q.items.PushBack(item)
+	return true
+}
+
+// Dequeue removes and returns the item at the front of the queue.
+// Returns false if the queue is empty.
+func (q *Queue[T]) Dequeue() (T, bool) {
+	return q.items.PopFront()
+}
+
+// Peek returns the item at the front of the queue without removing it.
+// Returns false if the queue is empty.
+func (q *Queue[T]) Peek() (T, bool) {
+	return q.items.PeekFront()
+}
+
+// Len returns the number of items in the queue.
+func (q *Queue[T]) Len() int {
+	return q.items.Len()
+}
+
+// IsEmpty returns true if the queue has no items.
+func (q *Queue[T]) IsEmpty() bool {
+	return q.items.IsEmpty()
+}
+
+// IsFull returns true if the queue is bounded and holds its maximum number of items.
+func (q *Queue[T]) IsFull() bool {
+	return q.capacity > 0 && q.items.Len() >= q.capacity
+}
@@ -0,0 +1,118 @@
+package devtoolkit
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+var (
+	ErrEmptyItems            = errors.New("items must not be empty")
+	ErrWeightsLengthMismatch = errors.New("items and weights must have the same length")
+	ErrInvalidWeights        = errors.New("weights must be non-negative and sum to a positive value")
+)
+
+// defaultRandom is the Random used by the package level helpers.
+var defaultRandom = NewRandom(time.Now().UnixNano())
+
+// Random is a seeded pseudo-random number generator safe for concurrent use.
+// Two instances created with the same seed produce the same sequence of values
+// when called in the same order, which makes sampling decisions reproducible.
+type Random struct {
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+// NewRandom creates a new Random seeded with the given value.
+func NewRandom(seed int64) *Random {
+	return &Random{rnd: rand.New(rand.NewSource(seed))}
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0).
+func (r *Random) Float64() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Float64()
+}
+
+// Intn returns a pseudo-random number in [0, n). It panics if n <= 0.
+func (r *Random) Intn(n int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Intn(n)
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer.
+func (r *Random) Int63() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Int63()
+}
+
+// Bernoulli returns true with probability p.
+// Values of p lower than 0 are treated as 0 and values greater than 1 as 1.
+func (r *Random) Bernoulli(p float64) bool {
+	if p <= 0 {
+		return false
+	}
+	if p >= 1 {
+		return true
+	}
+	return r.Float64() < p
+}
+
+// Bernoulli returns true with probability p using the package default Random.
+// Values of p lower than 0 are treated as 0 and values greater than 1 as 1.
+func Bernoulli(p float64) bool {
+	return defaultRandom.Bernoulli(p)
+}
+
+// WeightedChoice returns an item chosen at random, where the probability of choosing items[i]
+// is weights[i] divided by the sum of all weights. It uses the package default Random.
+func WeightedChoice[T any](items []T, weights []float64) (T, error) {
+	return WeightedChoiceWith(defaultRandom, items, weights)
+}
+
+// WeightedChoiceWith behaves like WeightedChoice but draws from the given Random,
+// allowing reproducible choices with a fixed seed.
+func WeightedChoiceWith[T any](r *Random, items []T, weights []float64) (T, error) {
+	var zero T
+	if len(items) == 0 {
+		return zero, ErrEmptyItems
+	}
+
+	if len(items) != len(weights) {
+		return zero, ErrWeightsLengthMismatch
+	}
+
+	var total float64
+	for _, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return zero, ErrInvalidWeights
+		}
+		total += w
+	}
+
+	if total <= 0 {
+		return zero, ErrInvalidWeights
+	}
+
+	target := r.Float64() * total
+	for i, w := range weights {
+		if target < w {
+			return items[i], nil
+		}
+		target -= w
+	}
+
+	// floating point rounding may leave target slightly above the last weight,
+	// fall back to the last item with a positive weight.
+	for i := len(weights) - 1; i >= 0; i-- {
+		if weights[i] > 0 {
+			return items[i], nil
+		}
+	}
+	return zero, ErrInvalidWeights
+}
@@ -0,0 +1,70 @@
+package devtoolkit
+
+import (
+	"cmp"
+	"golang.org/x/exp/constraints"
+	"slices"
+)
+
+// Range is a generic closed interval [Start, End] over ordered values.
+type Range[T constraints.Ordered] struct {
+	Start T
+	End   T
+}
+
+// NewRange returns a new Range between start and end.
+// If start is greater than end, the bounds are swapped.
+func NewRange[T constraints.Ordered](start, end T) Range[T] {
+	if start > end {
+		start, end = end, start
+	}
+	return Range[T]{Start: start, End: end}
+}
+
+// Contains returns true if value is within the range, bounds included.
+func (r Range[T]) Contains(value T) bool {
+	return value >= r.Start && value <= r.End
+}
+
+// ContainsRange returns true if other is fully contained within the range.
+func (r Range[T]) ContainsRange(other Range[T]) bool {
+	return other.Start >= r.Start && other.End <= r.End
+}
+
+// Overlaps returns true if the range and other share at least one value.
+func (r Range[T]) Overlaps(other Range[T]) bool {
+	return r.Start <= other.End && other.Start <= r.End
+}
+
+// Intersect returns the range shared by r and other.
+// Returns false if the ranges do not overlap.
+func (r Range[T]) Intersect(other Range[T]) (Range[T], bool) {
+	if !r.Overlaps(other) {
+		return Range[T]{}, false
+	}
+	return Range[T]{Start: max(r.Start, other.Start), End: min(r.End, other.End)}, true
+}
+
+// MergeRanges merges all overlapping ranges and returns the result sorted by Start.
+// The given slice is not modified.
+func MergeRanges[T constraints.Ordered](ranges []Range[T]) []Range[T] {
+	if len(ranges) == 0 {
+		return nil
+	}
+
+	var sorted = slices.Clone(ranges)
+	slices.SortFunc(sorted, func(a, b Range[T]) int {
+		return cmp.Compare(a.Start, b.Start)
+	})
+
+	var merged = []Range[T]{sorted[0]}
+	for _, r := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if last.Overlaps(r) {
+			last.End = max(last.End, r.End)
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
@@ -0,0 +1,32 @@
+package csv
+
+import (
+	"context"
+	"github.com/rendis/devtoolkit"
+	"iter"
+)
+
+// CheckpointedRows returns a sequence over the rows of r after the line number from.Line, passing the line number of
+// the last processed row to save, so an interrupted import restarts where it left off.
+// See devtoolkit.Checkpointed for when checkpoints are saved and how errors are reported.
+func CheckpointedRows(
+	ctx context.Context,
+	r Reader,
+	from devtoolkit.Checkpoint,
+	save devtoolkit.SaveCheckpoint,
+	optFns ...func(*devtoolkit.CheckpointOptions),
+) iter.Seq2[Row, error] {
+	var rows = func(yield func(Row, error) bool) {
+		for row := range r.Iterator() {
+			if !yield(row, nil) {
+				return
+			}
+		}
+	}
+
+	var position = func(row Row) devtoolkit.Checkpoint {
+		return devtoolkit.Checkpoint{Line: row.LineNumber()}
+	}
+
+	return devtoolkit.Checkpointed(ctx, rows, from, position, save, optFns...)
+}
@@ -1,7 +1,10 @@
 package csv
 
 import (
+	"bytes"
 	"encoding/csv"
+	"errors"
+	"fmt"
 	"github.com/jszwec/csvutil"
 	"io"
 	"os"
@@ -50,6 +53,7 @@ func NewCSVReader(r io.Reader, optFns ...func(*ReaderOptions)) (Reader, error) {
 
 	localReader := &csvReader{
 		trimHeader: defaultOpt.TrimHeader,
+		nulls:      newNullPolicy(defaultOpt),
 	}
 	reader := csv.NewReader(r)
 	reader.Comma = rune(defaultOpt.Separator)
@@ -78,12 +82,49 @@ func ToReaderSeparator(separator string) (ReaderSeparator, bool) {
 	}
 }
 
-func decodeObject(csvStr string, obj any) error {
-	reader := csv.NewReader(strings.NewReader(csvStr))
+// decodeObject decodes the records into obj, encoding them as CSV so quoted values keep their separators.
+func decodeObject(headers []string, records [][]string, obj any) error {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if len(headers) > 0 {
+		if err := writer.Write(headers); err != nil {
+			return err
+		}
+	}
+	if err := writer.WriteAll(records); err != nil {
+		return err
+	}
+
+	reader := csv.NewReader(&buf)
 	dec, err := csvutil.NewDecoder(reader)
 	if err != nil {
 		return err
 	}
 
-	return dec.Decode(obj)
+	parsers, err := columnParsersFor(obj)
+	if err != nil {
+		return err
+	}
+	if len(parsers) == 0 {
+		return dec.Decode(obj)
+	}
+
+	var parseErr error
+	dec.Map = func(field, column string, _ any) string {
+		parser, ok := parsers[column]
+		if !ok || parseErr != nil {
+			return field
+		}
+		parsed, err := parser(field)
+		if err != nil {
+			parseErr = fmt.Errorf("column '%s': %w", column, err)
+			return field
+		}
+		return parsed
+	}
+
+	if err := dec.Decode(obj); parseErr != nil || err != nil {
+		return errors.Join(parseErr, err)
+	}
+	return nil
 }
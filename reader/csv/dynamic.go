@@ -0,0 +1,145 @@
+package csv
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldKind is the kind of value of a SchemaField.
+type FieldKind int
+
+const (
+	StringField FieldKind = iota // decoded as string
+	IntField                     // decoded as int64
+	FloatField                   // decoded as float64
+	BoolField                    // decoded as bool
+	TimeField                    // decoded as time.Time, from RFC 3339 values
+)
+
+// SchemaField describes a column decoded into a DynamicObject.
+type SchemaField struct {
+	// Name is the name of the column.
+	Name string
+
+	// Kind is the kind of value the column is decoded as.
+	Kind FieldKind
+
+	// Parser is the name of a registered column parser applied to the value before decoding, optional.
+	Parser string
+}
+
+// Schema describes the columns of a DynamicObject, for decoding rows whose structure is not known at compile time.
+type Schema []SchemaField
+
+// DynamicObject is an object described by a Schema at runtime, that rows can be decoded into with
+// Row.ToObject, Reader.RowToObjet and Reader.ToObjects.
+type DynamicObject struct {
+	// Schema describes the columns to decode.
+	Schema Schema
+
+	// Values holds the decoded values by column name. Empty values of non-string kinds are decoded as nil.
+	Values map[string]any
+}
+
+// NewDynamicObject returns a new DynamicObject for the given schema.
+func NewDynamicObject(schema Schema) *DynamicObject {
+	return &DynamicObject{Schema: schema, Values: make(map[string]any, len(schema))}
+}
+
+// decodeRecord decodes a single record into obj, which can be a map[string]any, a pointer to one (allocated if nil),
+// a *DynamicObject or any object decoded by decodeObject.
+func decodeRecord(headers, record []string, obj any) error {
+	switch o := obj.(type) {
+	case map[string]any:
+		recordToMap(headers, record, o)
+		return nil
+	case *map[string]any:
+		if *o == nil {
+			*o = make(map[string]any, len(headers))
+		}
+		recordToMap(headers, record, *o)
+		return nil
+	case *DynamicObject:
+		return o.decode(headers, record)
+	default:
+		return decodeObject(headers, [][]string{record}, obj)
+	}
+}
+
+// recordToMap sets the values of record in m by column name.
+func recordToMap(headers, record []string, m map[string]any) {
+	for i, header := range headers {
+		if i < len(record) {
+			m[header] = record[i]
+		}
+	}
+}
+
+// decode decodes the columns of the schema from record into o.Values.
+func (o *DynamicObject) decode(headers, record []string) error {
+	if o.Values == nil {
+		o.Values = make(map[string]any, len(o.Schema))
+	}
+
+	for _, field := range o.Schema {
+		i := indexOfColumn(headers, field.Name)
+		if i < 0 || i >= len(record) {
+			return fmt.Errorf("unknown column '%s'", field.Name)
+		}
+
+		value, err := field.decode(record[i])
+		if err != nil {
+			return fmt.Errorf("column '%s': %w", field.Name, err)
+		}
+		o.Values[field.Name] = value
+	}
+	return nil
+}
+
+// decode applies the parser of the field to value and converts it to the kind of the field.
+func (f SchemaField) decode(value string) (any, error) {
+	if f.Parser != "" {
+		parser, err := getColumnParser(f.Parser)
+		if err != nil {
+			return nil, err
+		}
+		if value, err = parser(value); err != nil {
+			return nil, err
+		}
+	}
+
+	if f.Kind == StringField {
+		return value, nil
+	}
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	switch f.Kind {
+	case IntField:
+		return strconv.ParseInt(value, 10, 64)
+	case FloatField:
+		return strconv.ParseFloat(value, 64)
+	case BoolField:
+		return strconv.ParseBool(value)
+	case TimeField:
+		return time.Parse(time.RFC3339, value)
+	default:
+		return nil, fmt.Errorf("invalid field kind '%d'", f.Kind)
+	}
+}
+
+// indexOfColumn returns the index of the column in headers, matching its name with and without surrounding spaces,
+// or -1 if it is not found.
+func indexOfColumn(headers []string, column string) int {
+	for i, header := range headers {
+		if header == column || strings.TrimSpace(header) == column {
+			return i
+		}
+	}
+	return -1
+}
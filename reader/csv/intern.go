@@ -0,0 +1,41 @@
+package csv
+
+import "strings"
+
+// internRecords deduplicates the values of the interned columns of records in place, so repeated values share
+// a single string. The values of the other columns are cloned, releasing the line buffers allocated by
+// encoding/csv, which would otherwise be kept alive by any remaining field.
+func (c *csvReader) internRecords(records [][]string, opts *ReaderOptions) {
+	if !opts.InternAll && len(opts.InternColumns) == 0 {
+		return
+	}
+
+	var interned = make(map[int]map[string]string)
+	for _, column := range opts.InternColumns {
+		if i, ok := c.headerPosition[column]; ok {
+			interned[i] = make(map[string]string)
+		}
+	}
+
+	var all = make(map[string]string)
+	for _, record := range records {
+		for i, value := range record {
+			dict := interned[i]
+			if opts.InternAll {
+				dict = all
+			}
+			if dict == nil {
+				record[i] = strings.Clone(value)
+				continue
+			}
+
+			if v, ok := dict[value]; ok {
+				record[i] = v
+				continue
+			}
+			value = strings.Clone(value)
+			dict[value] = value
+			record[i] = value
+		}
+	}
+}
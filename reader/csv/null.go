@@ -0,0 +1,81 @@
+package csv
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNullValue is returned when decoding a row with a null value in a required column.
+var ErrNullValue = errors.New("null value in required column")
+
+// nullPolicy holds the null handling options used when decoding rows into objects.
+type nullPolicy struct {
+	values   map[string]struct{}
+	asNil    bool
+	required map[string]struct{}
+}
+
+// newNullPolicy returns the null policy for the given options, or nil if no null handling is configured.
+func newNullPolicy(opts *ReaderOptions) *nullPolicy {
+	if len(opts.NullValues) == 0 && !opts.NullAsNil && len(opts.RequiredColumns) == 0 {
+		return nil
+	}
+
+	var p = &nullPolicy{
+		values:   make(map[string]struct{}),
+		asNil:    opts.NullAsNil,
+		required: make(map[string]struct{}),
+	}
+
+	var values = opts.NullValues
+	if len(values) == 0 {
+		values = []string{""}
+	}
+	for _, v := range values {
+		p.values[v] = struct{}{}
+	}
+	for _, c := range opts.RequiredColumns {
+		p.required[c] = struct{}{}
+	}
+	return p
+}
+
+// apply checks the required columns of record and returns the record to decode,
+// with null values replaced by empty strings if asNil is set.
+// The given record is never modified.
+func (p *nullPolicy) apply(headers, record []string, lineNumber int) ([]string, error) {
+	if p == nil {
+		return record, nil
+	}
+
+	var applied = record
+	var copied bool
+	for i, value := range record {
+		if _, ok := p.values[value]; !ok {
+			continue
+		}
+
+		if i < len(headers) && p.isRequired(headers[i]) {
+			return nil, fmt.Errorf("line %d, column '%s': %w", lineNumber, strings.TrimSpace(headers[i]), ErrNullValue)
+		}
+
+		if p.asNil && value != "" {
+			if !copied {
+				applied = append([]string(nil), record...)
+				copied = true
+			}
+			applied[i] = ""
+		}
+	}
+	return applied, nil
+}
+
+// isRequired returns true if the column is required, matching its name with and without surrounding spaces.
+func (p *nullPolicy) isRequired(column string) bool {
+	if _, ok := p.required[column]; ok {
+		return true
+	}
+	_, ok := p.required[strings.TrimSpace(column)]
+	return ok
+}
@@ -0,0 +1,140 @@
+package csv
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ColumnParserTag is the struct tag used to apply a registered column parser to a field when decoding rows into objects.
+const ColumnParserTag = "csvparser"
+
+// ErrUnknownColumnParser is returned when a column parser name is not registered.
+var ErrUnknownColumnParser = errors.New("unknown column parser")
+
+// ColumnParser normalizes a raw cell value, e.g. a locale-specific date or boolean, into the canonical representation
+// decoded by ToObject: RFC 3339 for time.Time, "true"/"false" for bool, "." as decimal separator for numbers.
+type ColumnParser func(value string) (string, error)
+
+var (
+	columnParsersMu sync.RWMutex
+	columnParsers   = map[string]ColumnParser{
+		"date_dd/mm/yyyy": dateColumnParser("02/01/2006"),
+		"date_mm/dd/yyyy": dateColumnParser("01/02/2006"),
+		"bool_si/no":      boolColumnParser("si", "no"),
+		"bool_yes/no":     boolColumnParser("yes", "no"),
+		"decimal_comma":   decimalCommaColumnParser,
+	}
+)
+
+// RegisterColumnParser registers a column parser by name, replacing any parser with the same name.
+// Registered parsers are applied to the struct fields tagged with `csvparser:"<name>"` when decoding rows into objects,
+// and can be used on a row with Row.ParsedValue.
+func RegisterColumnParser(name string, parser ColumnParser) {
+	columnParsersMu.Lock()
+	defer columnParsersMu.Unlock()
+	columnParsers[name] = parser
+}
+
+// getColumnParser returns the column parser registered with the given name.
+func getColumnParser(name string) (ColumnParser, error) {
+	columnParsersMu.RLock()
+	defer columnParsersMu.RUnlock()
+	if p, ok := columnParsers[name]; ok {
+		return p, nil
+	}
+	return nil, fmt.Errorf("%w: '%s'", ErrUnknownColumnParser, name)
+}
+
+// columnParsersFor returns the column parsers declared with ColumnParserTag on the struct fields of the
+// decoded type of obj, by column name.
+func columnParsersFor(obj any) (map[string]ColumnParser, error) {
+	var t = reflect.TypeOf(obj)
+	for t != nil && (t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, nil
+	}
+
+	var parsers = make(map[string]ColumnParser)
+	if err := collectColumnParsers(t, parsers); err != nil {
+		return nil, err
+	}
+	return parsers, nil
+}
+
+func collectColumnParsers(t reflect.Type, parsers map[string]ColumnParser) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		csvTag, hasCSVTag := field.Tag.Lookup("csv")
+		column, _, _ := strings.Cut(csvTag, ",")
+		if column == "-" {
+			continue
+		}
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && !hasCSVTag && fieldType.Kind() == reflect.Struct {
+			if err := collectColumnParsers(fieldType, parsers); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name, ok := field.Tag.Lookup(ColumnParserTag)
+		if !ok {
+			continue
+		}
+		parser, err := getColumnParser(name)
+		if err != nil {
+			return fmt.Errorf("field '%s': %w", field.Name, err)
+		}
+		if column == "" {
+			column = field.Name
+		}
+		parsers[column] = parser
+	}
+	return nil
+}
+
+func dateColumnParser(layout string) ColumnParser {
+	return func(value string) (string, error) {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return value, nil
+		}
+		t, err := time.Parse(layout, value)
+		if err != nil {
+			return "", err
+		}
+		return t.Format(time.RFC3339), nil
+	}
+}
+
+func boolColumnParser(trueValue, falseValue string) ColumnParser {
+	return func(value string) (string, error) {
+		switch v := strings.TrimSpace(value); {
+		case v == "":
+			return v, nil
+		case strings.EqualFold(v, trueValue):
+			return "true", nil
+		case strings.EqualFold(v, falseValue):
+			return "false", nil
+		default:
+			return "", fmt.Errorf("invalid boolean value '%s', expected '%s' or '%s'", value, trueValue, falseValue)
+		}
+	}
+}
+
+// decimalCommaColumnParser parses numbers using ',' as decimal separator and '.' as thousands separator.
+func decimalCommaColumnParser(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	value = strings.ReplaceAll(value, ".", "")
+	return strings.Replace(value, ",", ".", 1), nil
+}
@@ -2,6 +2,7 @@ package csv
 
 import (
 	"encoding/csv"
+	"fmt"
 	"strings"
 )
 
@@ -37,14 +38,49 @@ type Reader interface {
 	// GetRow returns the row at the specified index.
 	GetRow(index int) (Row, bool)
 
-	// RowToObjet converts the row at the specified index to the specified object.
+	// RowToObjet converts the row at the specified index to the specified object, which can be a pointer to a struct,
+	// a map[string]any (or a pointer to one) receiving the values by column name, or a *DynamicObject.
 	RowToObjet(index int, obj any) (bool, error)
 
 	// GetNextIndex returns the next index based on the current index and cycle option.
 	GetNextIndex(currentIndex int, cycle bool) int
 
-	// ToObjects converts all rows to the specified slice of objects.
+	// ToObjects converts each row to the object at the same index of the specified slice, which must have one object
+	// per row. Objects can be of any of the kinds accepted by RowToObjet.
 	ToObjects(objs []any) error
+
+	// CountWhere returns the number of rows for which the predicate returns true.
+	CountWhere(predicate func(Row) bool) int
+
+	// Exists returns true if the predicate returns true for at least one row.
+	Exists(predicate func(Row) bool) bool
+
+	// DistinctValues returns the distinct values of the specified column name, in order of first appearance.
+	DistinctValues(columnName string) []string
+
+	// ValueCounts returns the number of occurrences of each value of the specified column name.
+	ValueCounts(columnName string) map[string]int
+
+	// SortBy sorts the rows in place by the specified columns, in order of priority.
+	// Rows with equal values keep their relative order. Row indexes and line numbers reflect the new order.
+	// Returns an error, leaving the rows untouched, if a column is unknown or a value cannot be parsed.
+	SortBy(specs ...SortSpec) error
+
+	// TopNBy returns the n rows with the greatest values of the specified column, from the greatest to the smallest,
+	// without sorting the rows. Values are compared as float64 numbers if numeric is true, and as strings otherwise.
+	// Rows with equal values keep their relative order. Returns an error if the column is unknown or a value cannot
+	// be parsed.
+	TopNBy(column string, n int, numeric bool) ([]Row, error)
+
+	// Pivot returns a new Reader with one row per distinct value of the rowKey column and one column per distinct
+	// value of the columnKey column, in order of first appearance. Each cell holds the result of aggFn over the
+	// valueColumn values of the matching rows, or an empty string if there are none.
+	// If aggFn is nil, the first value is used.
+	Pivot(rowKey, columnKey, valueColumn string, aggFn PivotAggFn) (Reader, error)
+
+	// Unpivot returns a new Reader where each row is expanded into one row per specified column, keeping the
+	// remaining columns and adding the UnpivotVariableColumn and UnpivotValueColumn columns.
+	Unpivot(columns ...string) (Reader, error)
 }
 
 // ReaderOptions holds options for configuring the CSV Reader.
@@ -52,6 +88,25 @@ type ReaderOptions struct {
 	NoHeader   bool
 	Separator  ReaderSeparator
 	TrimHeader bool
+
+	// NullValues are the values considered null when decoding rows into objects, e.g. "", "NULL" or "N/A".
+	// Defaults to the empty string if NullAsNil or RequiredColumns are set.
+	NullValues []string
+
+	// NullAsNil decodes null values as empty values: nil for pointer fields and "" for string fields.
+	// Otherwise, null values are decoded as they are.
+	NullAsNil bool
+
+	// RequiredColumns are the columns that must not hold a null value when decoding rows into objects.
+	// Decoding fails with ErrNullValue otherwise.
+	RequiredColumns []string
+
+	// InternColumns are the columns whose repeated values share a single string in memory (dictionary encoding),
+	// reducing the memory footprint of large files with categorical values.
+	InternColumns []string
+
+	// InternAll interns the values of all the columns.
+	InternAll bool
 }
 
 type csvReader struct {
@@ -59,6 +114,7 @@ type csvReader struct {
 	headerPosition map[string]int
 	records        [][]string
 	trimHeader     bool
+	nulls          *nullPolicy
 }
 
 func (c *csvReader) SetHeader(header []string) {
@@ -74,15 +130,8 @@ func (c *csvReader) SetHeader(header []string) {
 
 func (c *csvReader) Iterator() RowIterator {
 	return func(yield func(Row) bool) {
-		for i, record := range c.records {
-			r := &row{
-				row:            record,
-				headers:        c.headers,
-				headerPosition: c.headerPosition,
-				lineNumber:     i + 1,
-			}
-
-			if !yield(r) {
+		for i := range c.records {
+			if !yield(c.rowAt(i)) {
 				return
 			}
 		}
@@ -112,13 +161,7 @@ func (c *csvReader) GroupByColumnIndex(columnIndex int) map[string][]Row {
 		if _, ok := grouped[value]; !ok {
 			grouped[value] = make([]Row, 0)
 		}
-		r := &row{
-			row:            record,
-			headers:        c.headers,
-			headerPosition: c.headerPosition,
-			lineNumber:     i + 1,
-		}
-		grouped[value] = append(grouped[value], r)
+		grouped[value] = append(grouped[value], c.rowAt(i))
 	}
 	return grouped
 }
@@ -150,13 +193,7 @@ func (c *csvReader) GroupByColumnIndexes(columnIndexes ...int) map[string][]Row
 		if _, ok := grouped[groupKey]; !ok {
 			grouped[groupKey] = make([]Row, 0)
 		}
-		r := &row{
-			row:            record,
-			headers:        c.headers,
-			headerPosition: c.headerPosition,
-			lineNumber:     i + 1,
-		}
-		grouped[groupKey] = append(grouped[groupKey], r)
+		grouped[groupKey] = append(grouped[groupKey], c.rowAt(i))
 	}
 	return grouped
 }
@@ -183,12 +220,7 @@ func (c *csvReader) GetRow(index int) (Row, bool) {
 		return nil, false
 	}
 
-	return &row{
-		row:            c.records[index],
-		headers:        c.headers,
-		headerPosition: c.headerPosition,
-		lineNumber:     index + 1,
-	}, true
+	return c.rowAt(index), true
 }
 
 func (c *csvReader) RowToObjet(index int, obj any) (bool, error) {
@@ -210,16 +242,86 @@ func (c *csvReader) GetNextIndex(currentIndex int, cycle bool) int {
 }
 
 func (c *csvReader) ToObjects(objs []any) error {
-	var csvStr = ""
-	if len(c.headers) > 0 {
-		csvStr = strings.Join(c.headers, ",") + "\n"
+	if len(objs) != len(c.records) {
+		return fmt.Errorf("%d objects for %d rows", len(objs), len(c.records))
+	}
+
+	for i, record := range c.records {
+		record, err := c.nulls.apply(c.headers, record, i+1)
+		if err != nil {
+			return err
+		}
+		if err := decodeRecord(c.headers, record, objs[i]); err != nil {
+			return fmt.Errorf("line %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+func (c *csvReader) CountWhere(predicate func(Row) bool) int {
+	var count int
+	for i := range c.records {
+		if predicate(c.rowAt(i)) {
+			count++
+		}
+	}
+	return count
+}
+
+func (c *csvReader) Exists(predicate func(Row) bool) bool {
+	for i := range c.records {
+		if predicate(c.rowAt(i)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *csvReader) DistinctValues(columnName string) []string {
+	columnIndex, ok := c.headerPosition[columnName]
+	if !ok {
+		return nil
 	}
 
+	var seen = make(map[string]struct{})
+	var values = make([]string, 0)
 	for _, record := range c.records {
-		csvStr += strings.Join(record, ",") + "\n"
+		if columnIndex >= len(record) {
+			continue
+		}
+		value := record[columnIndex]
+		if _, ok := seen[value]; !ok {
+			seen[value] = struct{}{}
+			values = append(values, value)
+		}
 	}
+	return values
+}
 
-	return decodeObject(csvStr, objs)
+func (c *csvReader) ValueCounts(columnName string) map[string]int {
+	columnIndex, ok := c.headerPosition[columnName]
+	if !ok {
+		return nil
+	}
+
+	counts := make(map[string]int)
+	for _, record := range c.records {
+		if columnIndex < len(record) {
+			counts[record[columnIndex]]++
+		}
+	}
+	return counts
+}
+
+// rowAt returns the row for the record at the given index, which must be in range.
+func (c *csvReader) rowAt(index int) *row {
+	return &row{
+		row:            c.records[index],
+		headers:        c.headers,
+		headerPosition: c.headerPosition,
+		lineNumber:     index + 1,
+		nulls:          c.nulls,
+	}
 }
 
 func (c *csvReader) loadRows(reader *csv.Reader, opts *ReaderOptions) error {
@@ -232,11 +334,17 @@ func (c *csvReader) loadRows(reader *csv.Reader, opts *ReaderOptions) error {
 		return nil
 	}
 
+	// files written with a byte order mark, e.g. by Writer or Excel
+	if len(records[0]) > 0 {
+		records[0][0] = strings.TrimPrefix(records[0][0], utf8BOM)
+	}
+
 	if !opts.NoHeader {
 		c.SetHeader(records[0])
 		records = records[1:]
 	}
 
+	c.internRecords(records, opts)
 	c.records = records
 	return nil
 }
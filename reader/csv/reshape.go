@@ -0,0 +1,136 @@
+package csv
+
+import "fmt"
+
+const (
+	// UnpivotVariableColumn is the name of the column holding the unpivoted column names.
+	UnpivotVariableColumn = "variable"
+
+	// UnpivotValueColumn is the name of the column holding the unpivoted values.
+	UnpivotValueColumn = "value"
+)
+
+// PivotAggFn aggregates the values of the cells that fall into the same pivoted cell, in row order.
+type PivotAggFn func(values []string) string
+
+func (c *csvReader) Pivot(rowKey, columnKey, valueColumn string, aggFn PivotAggFn) (Reader, error) {
+	rowKeyIndex, err := c.columnIndex(rowKey)
+	if err != nil {
+		return nil, err
+	}
+	columnKeyIndex, err := c.columnIndex(columnKey)
+	if err != nil {
+		return nil, err
+	}
+	valueIndex, err := c.columnIndex(valueColumn)
+	if err != nil {
+		return nil, err
+	}
+
+	if aggFn == nil {
+		aggFn = func(values []string) string { return values[0] }
+	}
+
+	// distinct row and column keys in order of first appearance
+	var rowKeys, columnKeys []string
+	var rowPosition = make(map[string]int)
+	var columnPosition = make(map[string]int)
+	var cells = make(map[[2]int][]string)
+	for _, record := range c.records {
+		r, ok := rowPosition[record[rowKeyIndex]]
+		if !ok {
+			r = len(rowKeys)
+			rowPosition[record[rowKeyIndex]] = r
+			rowKeys = append(rowKeys, record[rowKeyIndex])
+		}
+		col, ok := columnPosition[record[columnKeyIndex]]
+		if !ok {
+			col = len(columnKeys)
+			columnPosition[record[columnKeyIndex]] = col
+			columnKeys = append(columnKeys, record[columnKeyIndex])
+		}
+		cells[[2]int{r, col}] = append(cells[[2]int{r, col}], record[valueIndex])
+	}
+
+	if _, ok := columnPosition[rowKey]; ok {
+		return nil, fmt.Errorf("pivot column value '%s' collides with row key column", rowKey)
+	}
+
+	var records = make([][]string, len(rowKeys))
+	for r, key := range rowKeys {
+		record := make([]string, len(columnKeys)+1)
+		record[0] = key
+		for col := range columnKeys {
+			if values, ok := cells[[2]int{r, col}]; ok {
+				record[col+1] = aggFn(values)
+			}
+		}
+		records[r] = record
+	}
+
+	return c.newDerived(append([]string{rowKey}, columnKeys...), records), nil
+}
+
+func (c *csvReader) Unpivot(columns ...string) (Reader, error) {
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("no columns to unpivot")
+	}
+
+	var unpivoted = make(map[int]bool, len(columns))
+	var unpivotedIndexes = make([]int, 0, len(columns))
+	for _, column := range columns {
+		i, err := c.columnIndex(column)
+		if err != nil {
+			return nil, err
+		}
+		unpivoted[i] = true
+		unpivotedIndexes = append(unpivotedIndexes, i)
+	}
+
+	var headers []string
+	var idIndexes []int
+	for i, header := range c.GetHeaders() {
+		if unpivoted[i] {
+			continue
+		}
+		if header == UnpivotVariableColumn || header == UnpivotValueColumn {
+			return nil, fmt.Errorf("column '%s' collides with unpivot output columns", header)
+		}
+		headers = append(headers, header)
+		idIndexes = append(idIndexes, i)
+	}
+	headers = append(headers, UnpivotVariableColumn, UnpivotValueColumn)
+
+	var records = make([][]string, 0, len(c.records)*len(unpivotedIndexes))
+	for _, record := range c.records {
+		for j, i := range unpivotedIndexes {
+			newRecord := make([]string, 0, len(headers))
+			for _, idIndex := range idIndexes {
+				newRecord = append(newRecord, record[idIndex])
+			}
+			newRecord = append(newRecord, columns[j], record[i])
+			records = append(records, newRecord)
+		}
+	}
+
+	return c.newDerived(headers, records), nil
+}
+
+// columnIndex returns the index of the specified column name, or an error if it is unknown.
+func (c *csvReader) columnIndex(columnName string) (int, error) {
+	if i, ok := c.headerPosition[columnName]; ok {
+		return i, nil
+	}
+	return 0, fmt.Errorf("unknown column '%s'", columnName)
+}
+
+// newDerived returns a new reader with the same options and the given headers and records.
+func (c *csvReader) newDerived(headers []string, records [][]string) *csvReader {
+	derived := &csvReader{
+		trimHeader: c.trimHeader,
+		records:    records,
+		nulls:      c.nulls,
+	}
+	derived.SetHeader(headers)
+	return derived
+}
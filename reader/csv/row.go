@@ -1,6 +1,6 @@
 package csv
 
-import "strings"
+import "fmt"
 
 // Row defines the interface for a row in the CSV file.
 type Row interface {
@@ -20,7 +20,11 @@ type Row interface {
 	LineNumber() int
 
 	// ToObject converts the row to the specified object.
+	// Fields tagged with `csvparser:"<name>"` are normalized with the registered column parser before decoding.
 	ToObject(obj any) error
+
+	// ParsedValue returns the value of the specified column name normalized with the registered column parser.
+	ParsedValue(columnName, parserName string) (string, error)
 }
 
 // RowField represents a field in a row with a name and value.
@@ -34,6 +38,7 @@ type row struct {
 	headers        []string
 	headerPosition map[string]int
 	lineNumber     int
+	nulls          *nullPolicy
 }
 
 func (r *row) Fields() []*RowField {
@@ -67,16 +72,37 @@ func (r *row) AsMap() map[string]string {
 	return m
 }
 
+func (r *row) ParsedValue(columnName, parserName string) (string, error) {
+	parser, err := getColumnParser(parserName)
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := r.Value(columnName)
+	if !ok {
+		return "", fmt.Errorf("unknown column '%s'", columnName)
+	}
+	return parser(value)
+}
+
 func (r *row) LineNumber() int {
 	return r.lineNumber
 }
 
 func (r *row) ToObject(obj any) error {
-	var csvStr = ""
-	if len(r.headers) > 0 {
-		csvStr = strings.Join(r.headers, ",") + "\n"
+	record, err := r.nulls.apply(r.headers, r.row, r.lineNumber)
+	if err != nil {
+		return err
 	}
-	csvStr += strings.Join(r.row, ",")
 
-	return decodeObject(csvStr, obj)
+	return decodeRecord(r.headers, record, obj)
+}
+
+// WhereColumn returns a row predicate that applies predicate to the value of the specified column name,
+// for use with CountWhere and Exists. It is false for rows without the column.
+func WhereColumn(columnName string, predicate func(string) bool) func(Row) bool {
+	return func(r Row) bool {
+		v, ok := r.Value(columnName)
+		return ok && predicate(v)
+	}
 }
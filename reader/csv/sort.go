@@ -0,0 +1,173 @@
+package csv
+
+import (
+	"fmt"
+	"github.com/rendis/devtoolkit"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SortType defines how the values of a column are compared when sorting.
+type SortType int
+
+const (
+	// SortString compares values as strings.
+	SortString SortType = iota
+
+	// SortNumeric compares values as float64 numbers.
+	SortNumeric
+
+	// SortDate compares values as dates parsed with the SortSpec layout.
+	SortDate
+)
+
+// SortSpec defines the sorting criteria for a column.
+type SortSpec struct {
+	// Column is the name of the column to sort by.
+	Column string
+
+	// Descending sorts the column in descending order, defaults to ascending.
+	Descending bool
+
+	// Type is the type hint used to compare the column values, defaults to SortString.
+	Type SortType
+
+	// Layout is the time layout used to parse the column values when Type is SortDate, defaults to time.RFC3339.
+	Layout string
+}
+
+// sortKey is a parsed column value.
+type sortKey struct {
+	str string
+	num float64
+	ts  time.Time
+}
+
+func (c *csvReader) SortBy(specs ...SortSpec) error {
+	if len(specs) == 0 || len(c.records) == 0 {
+		return nil
+	}
+
+	var columnIndexes = make([]int, len(specs))
+	for i, spec := range specs {
+		columnIndex, ok := c.headerPosition[spec.Column]
+		if !ok {
+			return fmt.Errorf("unknown sort column '%s'", spec.Column)
+		}
+		columnIndexes[i] = columnIndex
+	}
+
+	// parse all keys before sorting, so records are left untouched on error
+	var keys = make([][]sortKey, len(c.records))
+	for i, record := range c.records {
+		keys[i] = make([]sortKey, len(specs))
+		for j, spec := range specs {
+			key, err := parseSortKey(record[columnIndexes[j]], spec)
+			if err != nil {
+				return fmt.Errorf("row %d, column '%s': %w", i+1, spec.Column, err)
+			}
+			keys[i][j] = key
+		}
+	}
+
+	// compare row indexes by each spec, in order of priority
+	var comparator devtoolkit.Comparator[int]
+	for j, spec := range specs {
+		var specCmp = devtoolkit.Comparator[int](func(a, b int) int {
+			return compareSortKeys(keys[a][j], keys[b][j], spec.Type)
+		})
+		if spec.Descending {
+			specCmp = specCmp.Reversed()
+		}
+		if comparator == nil {
+			comparator = specCmp
+		} else {
+			comparator = comparator.ThenComparing(specCmp)
+		}
+	}
+
+	var order = make([]int, len(c.records))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, comparator)
+
+	var sorted = make([][]string, len(c.records))
+	for i, o := range order {
+		sorted[i] = c.records[o]
+	}
+	c.records = sorted
+	return nil
+}
+
+func parseSortKey(value string, spec SortSpec) (sortKey, error) {
+	switch spec.Type {
+	case SortNumeric:
+		num, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return sortKey{}, fmt.Errorf("invalid numeric value '%s'", value)
+		}
+		return sortKey{num: num}, nil
+	case SortDate:
+		layout := spec.Layout
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		ts, err := time.Parse(layout, strings.TrimSpace(value))
+		if err != nil {
+			return sortKey{}, fmt.Errorf("invalid date value '%s'", value)
+		}
+		return sortKey{ts: ts}, nil
+	default:
+		return sortKey{str: value}, nil
+	}
+}
+
+func compareSortKeys(a, b sortKey, sortType SortType) int {
+	switch sortType {
+	case SortNumeric:
+		switch {
+		case a.num < b.num:
+			return -1
+		case a.num > b.num:
+			return 1
+		}
+		return 0
+	case SortDate:
+		return a.ts.Compare(b.ts)
+	default:
+		return strings.Compare(a.str, b.str)
+	}
+}
+
+func (c *csvReader) TopNBy(column string, n int, numeric bool) ([]Row, error) {
+	columnIndex, ok := c.headerPosition[column]
+	if !ok {
+		return nil, fmt.Errorf("unknown column '%s'", column)
+	}
+
+	var spec = SortSpec{Column: column, Type: devtoolkit.IfThenElse(numeric, SortNumeric, SortString)}
+	var keys = make([]sortKey, len(c.records))
+	var indexes = make([]int, len(c.records))
+	for i, record := range c.records {
+		key, err := parseSortKey(record[columnIndex], spec)
+		if err != nil {
+			return nil, fmt.Errorf("row %d, column '%s': %w", i+1, column, err)
+		}
+		keys[i], indexes[i] = key, i
+	}
+
+	// among equal values, later rows are smaller, so the first ones are kept and returned first
+	top := devtoolkit.TopN(indexes, n, func(a, b int) bool {
+		cmp := compareSortKeys(keys[a], keys[b], spec.Type)
+		return cmp < 0 || (cmp == 0 && a > b)
+	})
+
+	var rows = make([]Row, len(top))
+	for i, index := range top {
+		rows[i] = c.rowAt(index)
+	}
+	return rows, nil
+}
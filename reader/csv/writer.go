@@ -0,0 +1,153 @@
+package csv
+
+import (
+	"encoding/csv"
+	"github.com/jszwec/csvutil"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// utf8BOM is the UTF-8 byte order mark, used by Excel to detect the encoding of CSV files.
+const utf8BOM = "\ufeff"
+
+// decimalCommaLanguages are the language codes of the locales using ',' as decimal separator.
+var decimalCommaLanguages = map[string]struct{}{
+	"be": {}, "bg": {}, "ca": {}, "cs": {}, "da": {}, "de": {}, "el": {}, "es": {}, "et": {}, "fi": {},
+	"fr": {}, "hr": {}, "hu": {}, "id": {}, "it": {}, "lt": {}, "lv": {}, "nb": {}, "nl": {}, "no": {},
+	"pl": {}, "pt": {}, "ro": {}, "ru": {}, "sk": {}, "sl": {}, "sr": {}, "sv": {}, "tr": {}, "uk": {}, "vi": {},
+}
+
+// WriterOptions holds options for configuring the CSV Writer.
+type WriterOptions struct {
+	// Separator is the field separator. Default is CommaSeparator.
+	Separator ReaderSeparator
+
+	// BOM indicates whether a UTF-8 byte order mark is written before the first record,
+	// so Excel detects the encoding. Default is false.
+	BOM bool
+
+	// CRLF indicates whether lines end with '\r\n' instead of '\n'. Default is false.
+	CRLF bool
+
+	// DecimalComma indicates whether the float values written by WriteObjects use ',' as decimal separator.
+	// Default is false.
+	DecimalComma bool
+}
+
+// LocaleOptions returns an option setting the separators Excel expects in the given locale (e.g. "es-CL", "de_DE"):
+// semicolon and decimal comma for the locales using ',' as decimal separator, comma and decimal point otherwise.
+func LocaleOptions(locale string) func(*WriterOptions) {
+	language, _, _ := strings.Cut(strings.ToLower(locale), "-")
+	language, _, _ = strings.Cut(language, "_")
+	_, decimalComma := decimalCommaLanguages[language]
+
+	return func(opts *WriterOptions) {
+		opts.Separator = CommaSeparator
+		if decimalComma {
+			opts.Separator = SemicolonSeparator
+		}
+		opts.DecimalComma = decimalComma
+	}
+}
+
+// ExcelOptions returns an option setting the separators of LocaleOptions, a UTF-8 byte order mark and CRLF line
+// endings, so the written files open correctly in Excel in the given locale.
+func ExcelOptions(locale string) func(*WriterOptions) {
+	localeOpts := LocaleOptions(locale)
+	return func(opts *WriterOptions) {
+		localeOpts(opts)
+		opts.BOM = true
+		opts.CRLF = true
+	}
+}
+
+// Writer writes CSV records and objects with the encoding options of WriterOptions.
+type Writer struct {
+	out     io.Writer
+	csv     *csv.Writer
+	opts    *WriterOptions
+	started bool
+}
+
+// NewCSVWriter creates a new CSV Writer writing to w with optional WriterOptions.
+// Writes are buffered; WriteAll and WriteObjects flush, Write requires a call to Flush.
+func NewCSVWriter(w io.Writer, optFns ...func(*WriterOptions)) *Writer {
+	defaultOpt := &WriterOptions{
+		Separator: CommaSeparator,
+	}
+
+	for _, o := range optFns {
+		o(defaultOpt)
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = rune(defaultOpt.Separator)
+	writer.UseCRLF = defaultOpt.CRLF
+
+	return &Writer{out: w, csv: writer, opts: defaultOpt}
+}
+
+// Write writes a single record, preceded by the byte order mark if it is the first one and BOM is set.
+func (w *Writer) Write(record []string) error {
+	if !w.started {
+		w.started = true
+		if w.opts.BOM {
+			if _, err := io.WriteString(w.out, utf8BOM); err != nil {
+				return err
+			}
+		}
+	}
+	return w.csv.Write(record)
+}
+
+// WriteAll writes the records and flushes the writer.
+func (w *Writer) WriteAll(records [][]string) error {
+	for _, record := range records {
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// WriteObjects writes a header, even if objs is empty, and one record per element of objs, a slice of structs or
+// pointers to structs, and flushes the writer. Columns are named by the 'csv' struct tags, as when reading, and float values use
+// ',' as decimal separator if DecimalComma is set.
+func (w *Writer) WriteObjects(objs any) error {
+	enc := csvutil.NewEncoder(w)
+	if w.opts.DecimalComma {
+		enc.WithMarshalers(csvutil.NewMarshalers(
+			csvutil.MarshalFunc(func(f float64) ([]byte, error) { return formatDecimalComma(f, 64), nil }),
+			csvutil.MarshalFunc(func(f float32) ([]byte, error) { return formatDecimalComma(float64(f), 32), nil }),
+		))
+	}
+
+	// the encoder writes the header with the first record
+	if v := reflect.ValueOf(objs); (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Len() == 0 {
+		elem := v.Type().Elem()
+		for elem.Kind() == reflect.Pointer {
+			elem = elem.Elem()
+		}
+		if err := enc.EncodeHeader(reflect.Zero(elem).Interface()); err != nil {
+			return err
+		}
+	}
+
+	if err := enc.Encode(objs); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// Flush writes any buffered data to the underlying io.Writer.
+func (w *Writer) Flush() error {
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+// formatDecimalComma formats f with ',' as decimal separator.
+func formatDecimalComma(f float64, bitSize int) []byte {
+	return []byte(strings.Replace(strconv.FormatFloat(f, 'f', -1, bitSize), ".", ",", 1))
+}
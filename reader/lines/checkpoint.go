@@ -0,0 +1,36 @@
+package lines
+
+import (
+	"context"
+	"github.com/rendis/devtoolkit"
+	"iter"
+)
+
+// ResumeFile returns a sequence over the lines of the file at path after the checkpoint from, passing the number
+// and NextOffset of the last processed line to save, so an interrupted read restarts where it left off.
+// The read starts at from.Offset, with the default ReaderOptions, and line numbers keep counting from from.Line.
+// See devtoolkit.Checkpointed for when checkpoints are saved and how errors are reported.
+func ResumeFile(
+	ctx context.Context,
+	path string,
+	from devtoolkit.Checkpoint,
+	save devtoolkit.SaveCheckpoint,
+	optFns ...func(*devtoolkit.CheckpointOptions),
+) iter.Seq2[Line, error] {
+	var lines = func(yield func(Line, error) bool) {
+		for line, err := range ReadFile(path, func(opts *ReaderOptions) { opts.StartOffset = from.Offset }) {
+			if err == nil {
+				line.Number += from.Line
+			}
+			if !yield(line, err) {
+				return
+			}
+		}
+	}
+
+	var position = func(line Line) devtoolkit.Checkpoint {
+		return devtoolkit.Checkpoint{Line: line.Number, Offset: line.NextOffset}
+	}
+
+	return devtoolkit.Checkpointed(ctx, lines, from, position, save, optFns...)
+}
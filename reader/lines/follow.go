@@ -0,0 +1,222 @@
+package lines
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"iter"
+	"os"
+	"time"
+)
+
+const defaultFollowPollInterval = 250 * time.Millisecond
+
+// FollowOptions holds options for configuring FollowFile.
+type FollowOptions struct {
+	// StartOffset is the byte offset where the read starts in the current file. Ignored if FromEnd is set.
+	StartOffset int64
+
+	// FromEnd starts the read at the end of the current file, so only the lines appended afterward are emitted.
+	FromEnd bool
+
+	// MaxLineSize is the maximum length in bytes of a line, defaults to 1MiB.
+	// Longer lines stop the iteration with ErrLineTooLong.
+	MaxLineSize int
+
+	// PollInterval is how often the file is checked for new lines, rotation and truncation, defaults to 250ms.
+	PollInterval time.Duration
+}
+
+// FollowFile returns a sequence over the lines of the file at path that keeps emitting new lines as they are
+// appended, like 'tail -f', until ctx is done. Only complete lines, ended by '\n', are emitted.
+//
+// When the file is rotated (path is replaced by a new file) the remaining lines of the old file are emitted and
+// the new file is read from its start; when it is truncated the read restarts from its start.
+// Offsets refer to the file being read at the time, while line numbers keep counting across rotations.
+// If the file does not exist yet, it is waited for.
+//
+// The iteration ends without error when ctx is done, so followers stop along with the rest of the application
+// on shutdown, and stops after the first error, which is yielded with an empty Line.
+func FollowFile(ctx context.Context, path string, optFns ...func(*FollowOptions)) iter.Seq2[Line, error] {
+	var opts = &FollowOptions{}
+	for _, fn := range optFns {
+		fn(opts)
+	}
+	if opts.MaxLineSize <= 0 {
+		opts.MaxLineSize = defaultMaxLineSize
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultFollowPollInterval
+	}
+
+	return func(yield func(Line, error) bool) {
+		f := &follower{path: path, opts: opts}
+		defer f.close()
+
+		if err := f.open(ctx, true); err != nil {
+			if ctx.Err() == nil {
+				yield(Line{}, err)
+			}
+			return
+		}
+
+		for {
+			line, ok, err := f.next()
+			if err != nil {
+				yield(Line{}, err)
+				return
+			}
+			if ok {
+				if !yield(line, nil) {
+					return
+				}
+				continue
+			}
+
+			// no complete line available, wait and check for rotation or truncation.
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(opts.PollInterval):
+			}
+
+			if err = f.checkFile(ctx); err != nil {
+				if ctx.Err() == nil {
+					yield(Line{}, err)
+				}
+				return
+			}
+		}
+	}
+}
+
+type follower struct {
+	path    string
+	opts    *FollowOptions
+	file    *os.File
+	info    fs.FileInfo
+	br      *bufio.Reader
+	pending []byte // read bytes of an incomplete line
+	offset  int64  // offset of the start of pending
+	number  int
+	rotated bool // the path points to a new file, pending the old one being drained
+}
+
+// open opens the file at path, waiting for it to exist, and positions it at the start offset if first is true,
+// or at its start otherwise.
+func (f *follower) open(ctx context.Context, first bool) error {
+	for {
+		file, err := os.Open(f.path)
+		if err == nil {
+			f.close()
+			f.file = file
+			break
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("error opening file '%s': %w", f.path, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(f.opts.PollInterval):
+		}
+	}
+
+	info, err := f.file.Stat()
+	if err != nil {
+		return fmt.Errorf("error getting file info of '%s': %w", f.path, err)
+	}
+	f.info = info
+
+	var offset int64
+	if first {
+		offset = f.opts.StartOffset
+		if f.opts.FromEnd {
+			offset = info.Size()
+		}
+	}
+	return f.seek(offset)
+}
+
+func (f *follower) seek(offset int64) error {
+	if _, err := f.file.Seek(offset, io.SeekStart); err != nil {
+		return fmt.Errorf("error moving to offset %d of '%s': %w", offset, f.path, err)
+	}
+	f.br = bufio.NewReaderSize(f.file, readBufferSize)
+	f.pending = f.pending[:0]
+	f.offset = offset
+	return nil
+}
+
+// next returns the next complete line, or false if none is available yet.
+func (f *follower) next() (Line, bool, error) {
+	for {
+		chunk, err := f.br.ReadSlice('\n')
+		if len(trimEOL(f.pending))+len(trimEOL(chunk)) > f.opts.MaxLineSize {
+			return Line{}, false, fmt.Errorf("error reading line %d at offset %d: %w", f.number+1, f.offset, ErrLineTooLong)
+		}
+		f.pending = append(f.pending, chunk...)
+
+		switch {
+		case err == nil:
+			f.number++
+			line := Line{
+				Text:       string(trimEOL(f.pending)),
+				Number:     f.number,
+				Offset:     f.offset,
+				NextOffset: f.offset + int64(len(f.pending)),
+			}
+			f.offset = line.NextOffset
+			f.pending = f.pending[:0]
+			return line, true, nil
+		case err == bufio.ErrBufferFull:
+			continue
+		case err == io.EOF:
+			return Line{}, false, nil
+		default:
+			return Line{}, false, fmt.Errorf("error reading line %d at offset %d: %w", f.number+1, f.offset, err)
+		}
+	}
+}
+
+// checkFile reopens the file if it was rotated, once the old one is drained, or rewinds it if it was truncated.
+func (f *follower) checkFile(ctx context.Context) error {
+	if f.rotated {
+		// the old file was drained by the last reads.
+		f.rotated = false
+		f.pending = f.pending[:0]
+		return f.open(ctx, false)
+	}
+
+	info, err := os.Stat(f.path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		// removed, keep reading the old file until a new one is created.
+		return nil
+	case err != nil:
+		return fmt.Errorf("error getting file info of '%s': %w", f.path, err)
+	case !os.SameFile(info, f.info):
+		// rotated, drain the old file before switching.
+		f.rotated = true
+		return nil
+	}
+
+	current, err := f.file.Stat()
+	if err != nil {
+		return fmt.Errorf("error getting file info of '%s': %w", f.path, err)
+	}
+	if current.Size() < f.offset+int64(len(f.pending)) {
+		return f.seek(0)
+	}
+	return nil
+}
+
+func (f *follower) close() {
+	if f.file != nil {
+		_ = f.file.Close()
+	}
+}
@@ -0,0 +1,195 @@
+// Package lines streams the lines of large files with bounded memory, reporting the byte offset of each line
+// so a read can be resumed later from a checkpoint. It is useful for logs and for pre-processing files
+// before reading them with the CSV reader.
+package lines
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+)
+
+const (
+	defaultMaxLineSize      = 1 << 20 // 1MiB
+	defaultProgressInterval = 1 << 20 // 1MiB
+	readBufferSize          = 64 << 10
+)
+
+// ErrLineTooLong is returned when a line is longer than ReaderOptions.MaxLineSize.
+var ErrLineTooLong = errors.New("line too long")
+
+// Line is a line read from a file.
+type Line struct {
+	// Text is the content of the line, without the trailing '\n' or '\r\n'.
+	Text string
+
+	// Number is the 1-based number of the line, counted from the start offset of the read.
+	Number int
+
+	// Offset is the byte offset of the start of the line.
+	Offset int64
+
+	// NextOffset is the byte offset of the line after this one. Use it as ReaderOptions.StartOffset
+	// to resume the read after this line.
+	NextOffset int64
+}
+
+// Progress is the state of a read, reported to ReaderOptions.Progress.
+type Progress struct {
+	// Offset is the byte offset up to which the input has been read.
+	Offset int64
+
+	// TotalBytes is the size of the input, or 0 if unknown.
+	TotalBytes int64
+
+	// Lines is the number of lines read.
+	Lines int
+}
+
+// ReaderOptions holds options for configuring the line reader.
+type ReaderOptions struct {
+	// StartOffset is the byte offset where the read starts, usually the NextOffset of the last processed line.
+	StartOffset int64
+
+	// MaxLineSize is the maximum length in bytes of a line, defaults to 1MiB.
+	// Longer lines stop the read with ErrLineTooLong, bounding the memory used.
+	MaxLineSize int
+
+	// Progress, if not nil, is called every ProgressInterval bytes and when the read ends.
+	Progress func(Progress)
+
+	// ProgressInterval is the number of bytes between Progress calls, defaults to 1MiB.
+	ProgressInterval int64
+}
+
+// ReadFile returns a sequence over the lines of the file at path.
+// The iteration stops after the first error, which is yielded with an empty Line.
+func ReadFile(path string, optFns ...func(*ReaderOptions)) iter.Seq2[Line, error] {
+	return func(yield func(Line, error) bool) {
+		file, err := os.Open(path)
+		if err != nil {
+			yield(Line{}, fmt.Errorf("error opening file '%s': %w", path, err))
+			return
+		}
+		defer file.Close()
+
+		var totalBytes int64
+		if info, err := file.Stat(); err == nil {
+			totalBytes = info.Size()
+		}
+
+		read(file, totalBytes, newOptions(optFns), yield)
+	}
+}
+
+// Read returns a sequence over the lines of r. If StartOffset is set, r is positioned with Seek when it
+// implements io.Seeker, otherwise the first StartOffset bytes are discarded.
+// The iteration stops after the first error, which is yielded with an empty Line.
+func Read(r io.Reader, optFns ...func(*ReaderOptions)) iter.Seq2[Line, error] {
+	return func(yield func(Line, error) bool) {
+		read(r, 0, newOptions(optFns), yield)
+	}
+}
+
+func newOptions(optFns []func(*ReaderOptions)) *ReaderOptions {
+	var opts = &ReaderOptions{}
+	for _, fn := range optFns {
+		fn(opts)
+	}
+	if opts.MaxLineSize <= 0 {
+		opts.MaxLineSize = defaultMaxLineSize
+	}
+	if opts.ProgressInterval <= 0 {
+		opts.ProgressInterval = defaultProgressInterval
+	}
+	return opts
+}
+
+func read(r io.Reader, totalBytes int64, opts *ReaderOptions, yield func(Line, error) bool) {
+	if err := skipTo(r, opts.StartOffset); err != nil {
+		yield(Line{}, fmt.Errorf("error moving to offset %d: %w", opts.StartOffset, err))
+		return
+	}
+
+	var offset = opts.StartOffset
+	var number int
+	var nextProgress = offset + opts.ProgressInterval
+	var reportProgress = func() {
+		if opts.Progress != nil {
+			opts.Progress(Progress{Offset: offset, TotalBytes: totalBytes, Lines: number})
+		}
+	}
+	defer reportProgress()
+
+	br := bufio.NewReaderSize(r, readBufferSize)
+	for {
+		raw, err := readLine(br, opts.MaxLineSize)
+		if len(raw) > 0 {
+			number++
+			line := Line{
+				Text:       string(trimEOL(raw)),
+				Number:     number,
+				Offset:     offset,
+				NextOffset: offset + int64(len(raw)),
+			}
+			offset = line.NextOffset
+
+			if !yield(line, nil) {
+				return
+			}
+
+			if offset >= nextProgress {
+				reportProgress()
+				nextProgress = offset + opts.ProgressInterval
+			}
+		}
+
+		if err != nil {
+			if err != io.EOF {
+				yield(Line{}, fmt.Errorf("error reading line %d at offset %d: %w", number+1, offset, err))
+			}
+			return
+		}
+	}
+}
+
+// readLine returns the next line including its '\n' terminator, if any.
+// Returns ErrLineTooLong, and no line, if the line exceeds maxLineSize without its terminator.
+func readLine(br *bufio.Reader, maxLineSize int) ([]byte, error) {
+	var line []byte
+	for {
+		chunk, err := br.ReadSlice('\n')
+		if len(trimEOL(line))+len(trimEOL(chunk)) > maxLineSize {
+			return nil, ErrLineTooLong
+		}
+		line = append(line, chunk...)
+		if err != bufio.ErrBufferFull {
+			return line, err
+		}
+	}
+}
+
+func trimEOL(b []byte) []byte {
+	if n := len(b); n > 0 && b[n-1] == '\n' {
+		b = b[:n-1]
+		if n := len(b); n > 0 && b[n-1] == '\r' {
+			b = b[:n-1]
+		}
+	}
+	return b
+}
+
+func skipTo(r io.Reader, offset int64) error {
+	if offset <= 0 {
+		return nil
+	}
+	if s, ok := r.(io.Seeker); ok {
+		_, err := s.Seek(offset, io.SeekStart)
+		return err
+	}
+	_, err := io.CopyN(io.Discard, r, offset)
+	return err
+}
@@ -0,0 +1,111 @@
+package devtoolkit
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+)
+
+// ErrReadLimitExceeded is returned by the reader of LimitReaderWithErr when the input exceeds the limit.
+var ErrReadLimitExceeded = errors.New("read limit exceeded")
+
+// LimitReaderWithErr returns a reader that reads from r up to n bytes and fails with ErrReadLimitExceeded
+// if r holds more, unlike io.LimitReader, which silently truncates the input with io.EOF.
+// It protects against abusive inputs without mistaking them for valid, shorter ones.
+func LimitReaderWithErr(r io.Reader, n int64) io.Reader {
+	return &limitedErrReader{r: r, remaining: n}
+}
+
+type limitedErrReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (l *limitedErrReader) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		return 0, ErrReadLimitExceeded
+	}
+
+	// read one byte past the limit to detect whether the input exceeds it.
+	if int64(len(p))-1 > l.remaining {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.r.Read(p)
+	if int64(n) > l.remaining {
+		n = int(l.remaining)
+		l.remaining = -1
+		return n, ErrReadLimitExceeded
+	}
+	l.remaining -= int64(n)
+	return n, err
+}
+
+// TimeoutReader returns a reader that reads from r until ctx is done, then fails with ctx.Err(),
+// even if a read from r is blocked. After a read is abandoned, r must not be used anymore,
+// since the blocked read may still complete in the background.
+func TimeoutReader(ctx context.Context, r io.Reader) io.Reader {
+	return &timeoutReader{ctx: ctx, r: r}
+}
+
+type timeoutReader struct {
+	ctx context.Context
+	r   io.Reader
+	err error
+}
+
+type readResult struct {
+	n   int
+	err error
+}
+
+func (t *timeoutReader) Read(p []byte) (int, error) {
+	if t.err != nil {
+		return 0, t.err
+	}
+	if err := t.ctx.Err(); err != nil {
+		t.err = err
+		return 0, err
+	}
+
+	// read into a separate buffer, so an abandoned read never writes into p after returning.
+	var buf = make([]byte, len(p))
+	var ch = make(chan readResult, 1)
+	go func() {
+		n, err := t.r.Read(buf)
+		ch <- readResult{n: n, err: err}
+	}()
+
+	select {
+	case res := <-ch:
+		copy(p, buf[:res.n])
+		return res.n, res.err
+	case <-t.ctx.Done():
+		t.err = t.ctx.Err()
+		return 0, t.err
+	}
+}
+
+// CountingReader is a reader that counts the bytes read from the underlying reader.
+// The count can be read concurrently, e.g. to report progress.
+type CountingReader struct {
+	r io.Reader
+	n atomic.Int64
+}
+
+// NewCountingReader returns a new CountingReader reading from r.
+func NewCountingReader(r io.Reader) *CountingReader {
+	return &CountingReader{r: r}
+}
+
+// Read reads from the underlying reader, adding the bytes read to the count.
+func (c *CountingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n.Add(int64(n))
+	return n, err
+}
+
+// BytesRead returns the number of bytes read so far.
+func (c *CountingReader) BytesRead() int64 {
+	return c.n.Load()
+}
@@ -1,6 +1,7 @@
 package devtoolkit
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
@@ -11,11 +12,24 @@ var (
 	defaultWaitTime       = 100 * time.Millisecond
 )
 
+// ErrAttemptTimeout is returned by an attempt of an operation that exceeds ResilienceOptions.AttemptTimeout.
+var ErrAttemptTimeout = errors.New("attempt timed out")
+
 // Resilience provides an interface for retrying operations in case of failure.
 type Resilience interface {
 	RetryOperation(operation func() error) error
 }
 
+// ContextResilience is a Resilience that also retries context-aware operations.
+type ContextResilience interface {
+	Resilience
+
+	// RetryOperationWithContext retries operation like RetryOperation, passing it the context of the attempt, which
+	// has the deadline of ResilienceOptions.AttemptTimeout. Waits between retries are interrupted when ctx is done,
+	// returning its error along with the last one.
+	RetryOperationWithContext(ctx context.Context, operation func(ctx context.Context) error) error
+}
+
 // ResilienceOptions contains configuration parameters for retry operations.
 type ResilienceOptions struct {
 	MaxRetries              int              // indicates the maximum number of retries. Default is 3.
@@ -24,12 +38,42 @@ type ResilienceOptions struct {
 	RawError                bool             // indicates whether to return the raw error or wrap it in a new error. Default is false.
 	IsIgnorableErrorHandler func(error) bool // indicates whether to ignore the error or not. Default is nil.
 	ReturnIgnorable         bool             // indicates whether to return the ignorable error or not. Default is false.
+	AttemptTimeout          time.Duration    // indicates the maximum duration of each attempt, after which it fails with ErrAttemptTimeout. Default is 0 (no timeout).
 }
 
 // NewResilience returns a new Resilience instance with the provided options or defaults.
 func NewResilience(options *ResilienceOptions) (Resilience, error) {
-	if options == nil {
-		options = &ResilienceOptions{}
+	return NewResilienceWithOptions(func(o *ResilienceOptions) {
+		if options != nil {
+			*o = *options
+		}
+	})
+}
+
+// NewResilienceWithOptions returns a new Resilience instance with the defaults modified by the given option functions,
+// e.g. NewResilienceWithOptions(WithMaxRetries(5), WithBackoff()).
+func NewResilienceWithOptions(optFns ...func(*ResilienceOptions)) (Resilience, error) {
+	r, err := newResilience(optFns...)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// NewContextResilience returns a new ContextResilience instance with the defaults modified by the given option
+// functions, e.g. NewContextResilience(WithAttemptTimeout(2*time.Second), WithBackoff()).
+func NewContextResilience(optFns ...func(*ResilienceOptions)) (ContextResilience, error) {
+	r, err := newResilience(optFns...)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func newResilience(optFns ...func(*ResilienceOptions)) (*resilience, error) {
+	var options = &ResilienceOptions{}
+	for _, fn := range optFns {
+		fn(options)
 	}
 
 	if options.MaxRetries < 0 {
@@ -48,18 +92,71 @@ func NewResilience(options *ResilienceOptions) (Resilience, error) {
 		options.WaitTime = defaultWaitTime
 	}
 
+	if options.AttemptTimeout < 0 {
+		return nil, errors.New("AttemptTimeout cannot be negative")
+	}
+
 	return &resilience{*options}, nil
 }
 
+// WithMaxRetries sets ResilienceOptions.MaxRetries.
+func WithMaxRetries(maxRetries int) func(*ResilienceOptions) {
+	return func(o *ResilienceOptions) {
+		o.MaxRetries = maxRetries
+	}
+}
+
+// WithWaitTime sets ResilienceOptions.WaitTime.
+func WithWaitTime(waitTime time.Duration) func(*ResilienceOptions) {
+	return func(o *ResilienceOptions) {
+		o.WaitTime = waitTime
+	}
+}
+
+// WithBackoff enables the exponential backoff of ResilienceOptions.Backoff.
+func WithBackoff() func(*ResilienceOptions) {
+	return func(o *ResilienceOptions) {
+		o.Backoff = true
+	}
+}
+
+// WithRawError enables ResilienceOptions.RawError.
+func WithRawError() func(*ResilienceOptions) {
+	return func(o *ResilienceOptions) {
+		o.RawError = true
+	}
+}
+
+// WithIgnorableErrors sets ResilienceOptions.IsIgnorableErrorHandler and ResilienceOptions.ReturnIgnorable.
+func WithIgnorableErrors(isIgnorable func(error) bool, returnIgnorable bool) func(*ResilienceOptions) {
+	return func(o *ResilienceOptions) {
+		o.IsIgnorableErrorHandler = isIgnorable
+		o.ReturnIgnorable = returnIgnorable
+	}
+}
+
+// WithAttemptTimeout sets ResilienceOptions.AttemptTimeout.
+func WithAttemptTimeout(timeout time.Duration) func(*ResilienceOptions) {
+	return func(o *ResilienceOptions) {
+		o.AttemptTimeout = timeout
+	}
+}
+
 type resilience struct {
 	ResilienceOptions
 }
 
 func (r *resilience) RetryOperation(operation func() error) error {
+	return r.RetryOperationWithContext(context.Background(), func(context.Context) error {
+		return operation()
+	})
+}
+
+func (r *resilience) RetryOperationWithContext(ctx context.Context, operation func(ctx context.Context) error) error {
 	var lastErr error
 	waitTime := r.WaitTime
 	for i := 0; i < r.MaxRetries; i++ {
-		lastErr = operation()
+		lastErr = r.attempt(ctx, operation)
 		if lastErr == nil {
 			return nil
 		}
@@ -71,11 +168,14 @@ func (r *resilience) RetryOperation(operation func() error) error {
 			return nil
 		}
 
+		if err := sleepContext(ctx, waitTime); err != nil {
+			if errors.Is(lastErr, err) {
+				return lastErr
+			}
+			return errors.Join(lastErr, err)
+		}
 		if r.Backoff {
-			time.Sleep(waitTime)
 			waitTime *= 2 // exponential backoff.
-		} else {
-			time.Sleep(r.WaitTime)
 		}
 	}
 
@@ -84,3 +184,42 @@ func (r *resilience) RetryOperation(operation func() error) error {
 	}
 	return errors.Join(lastErr, errors.New(fmt.Sprintf("max retries exceeded (%d)", r.MaxRetries)))
 }
+
+// attempt runs operation once. If AttemptTimeout is set, operation runs in its own goroutine and is abandoned when
+// the timeout expires, so operations that ignore their context can't block the retries.
+func (r *resilience) attempt(ctx context.Context, operation func(ctx context.Context) error) error {
+	if r.AttemptTimeout <= 0 {
+		return operation(ctx)
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, r.AttemptTimeout)
+	defer cancel()
+
+	var done = make(chan error, 1)
+	go func() {
+		done <- operation(attemptCtx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-attemptCtx.Done():
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w after %s", ErrAttemptTimeout, r.AttemptTimeout)
+	}
+}
+
+// sleepContext waits for d, or returns the error of ctx if it is done first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
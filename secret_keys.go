@@ -0,0 +1,40 @@
+package devtoolkit
+
+import (
+	"strings"
+	"sync"
+)
+
+var (
+	secretKeyMarkersMu sync.RWMutex
+
+	// secretKeyMarkers are the (lower-case) substrings that identify a key holding a secret, whose values are masked
+	// by DiffPropFiles and redacted from panic reports.
+	secretKeyMarkers = []string{
+		"password", "passwd", "secret", "token", "authorization",
+		"apikey", "api-key", "api_key", "private-key", "private_key", "credential",
+	}
+)
+
+// RegisterSecretKeyMarker registers additional key substrings (case-insensitive) identifying secrets, whose values
+// are masked by DiffPropFiles and redacted from the metadata of panic reports.
+func RegisterSecretKeyMarker(markers ...string) {
+	secretKeyMarkersMu.Lock()
+	defer secretKeyMarkersMu.Unlock()
+	for _, marker := range markers {
+		secretKeyMarkers = append(secretKeyMarkers, strings.ToLower(marker))
+	}
+}
+
+// isSecretKey returns true if key contains one of the secret key markers, ignoring case.
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	secretKeyMarkersMu.RLock()
+	defer secretKeyMarkersMu.RUnlock()
+	for _, marker := range secretKeyMarkers {
+		if strings.Contains(key, marker) {
+			return true
+		}
+	}
+	return false
+}
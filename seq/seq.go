@@ -0,0 +1,150 @@
+// Package seq provides lazy operations over Go 1.23 iterators (iter.Seq), allowing large collections
+// to be processed without materializing intermediate slices.
+//
+// Push iterators defined elsewhere in devtoolkit, such as csv.RowIterator, can be converted directly:
+//
+//	rows := iter.Seq[csv.Row](reader.Iterator())
+package seq
+
+import "iter"
+
+// FromSlice returns a sequence over the items of slice.
+func FromSlice[T any](slice []T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, s := range slice {
+			if !yield(s) {
+				return
+			}
+		}
+	}
+}
+
+// FromChannel returns a sequence over the values received from ch until it is closed.
+// Stopping the iteration early leaves the remaining values in ch.
+func FromChannel[T any](ch <-chan T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range ch {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice collects all the values of seq into a new slice.
+func ToSlice[T any](seq iter.Seq[T]) []T {
+	var slice []T
+	for v := range seq {
+		slice = append(slice, v)
+	}
+	return slice
+}
+
+// CollectErr collects the values of seq into a new slice, stopping at the first non-nil error,
+// which is returned along with the values collected before it.
+func CollectErr[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var slice []T
+	for v, err := range seq {
+		if err != nil {
+			return slice, err
+		}
+		slice = append(slice, v)
+	}
+	return slice, nil
+}
+
+// Map returns a sequence with the results of applying mapper to each value of seq.
+func Map[T, R any](seq iter.Seq[T], mapper func(T) R) iter.Seq[R] {
+	return func(yield func(R) bool) {
+		for v := range seq {
+			if !yield(mapper(v)) {
+				return
+			}
+		}
+	}
+}
+
+// Filter returns a sequence with the values of seq for which predicate returns true.
+func Filter[T any](seq iter.Seq[T], predicate func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			if predicate(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Take returns a sequence with at most the first n values of seq.
+func Take[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+
+		var taken int
+		for v := range seq {
+			if !yield(v) {
+				return
+			}
+			taken++
+			if taken >= n {
+				return
+			}
+		}
+	}
+}
+
+// Skip returns a sequence with the values of seq after the first n.
+func Skip[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var skipped int
+		for v := range seq {
+			if skipped < n {
+				skipped++
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Chunk returns a sequence of slices with size consecutive values of seq. The last chunk may contain fewer values.
+// Each chunk is a new slice. The sequence is empty if size is not positive.
+func Chunk[T any](seq iter.Seq[T], size int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if size <= 0 {
+			return
+		}
+
+		var chunk = make([]T, 0, size)
+		for v := range seq {
+			chunk = append(chunk, v)
+			if len(chunk) == size {
+				if !yield(chunk) {
+					return
+				}
+				chunk = make([]T, 0, size)
+			}
+		}
+
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// Peek returns a sequence with the values of seq, calling fn with each value before yielding it,
+// e.g. for logging or metrics.
+func Peek[T any](seq iter.Seq[T], fn func(T)) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			fn(v)
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package devtoolkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	// ErrSerialExecutorFull is returned by SerialExecutor.Submit when the queue is full.
+	ErrSerialExecutorFull = errors.New("serial executor queue is full")
+
+	// ErrSerialExecutorClosed is returned when submitting to a SerialExecutor that was shut down.
+	ErrSerialExecutorClosed = errors.New("serial executor is closed")
+)
+
+// SerialExecutor runs the submitted functions one at a time, in submission order, on a dedicated goroutine.
+// Functions run by the same executor never overlap, so state only touched from them needs no locking
+// (actor style), which is simpler than guarding complex state with mutexes.
+// A panic in a function is recovered, reported as a *PanicReport by SubmitWait, and does not stop the executor.
+type SerialExecutor struct {
+	queue   chan serialTask
+	closing chan struct{}  // closed by Shutdown; queue is never closed, since senders may still use it
+	done    chan struct{}  // closed when the executor goroutine exits
+	sending sync.WaitGroup // sends in flight, which the executor waits for before exiting
+	closed  bool
+	mu      sync.RWMutex // guards closed; never held while blocking
+}
+
+type serialTask struct {
+	fn     func()
+	result chan error // nil if nobody waits for the result
+}
+
+// NewSerialExecutor returns a new running SerialExecutor whose queue holds up to queueSize pending functions
+// (unbuffered if not positive).
+func NewSerialExecutor(queueSize int) *SerialExecutor {
+	var se = &SerialExecutor{
+		queue:   make(chan serialTask, max(queueSize, 0)),
+		closing: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go se.loop()
+	return se
+}
+
+// Submit enqueues fn without waiting for it to run.
+// Returns ErrSerialExecutorFull if the queue is full, or ErrSerialExecutorClosed if the executor was shut down.
+func (se *SerialExecutor) Submit(fn func()) error {
+	if err := se.beginSend(); err != nil {
+		return err
+	}
+	defer se.sending.Done()
+
+	select {
+	case se.queue <- serialTask{fn: fn}:
+		return nil
+	default:
+		return ErrSerialExecutorFull
+	}
+}
+
+// SubmitWait enqueues fn, waiting for room in the queue if needed, and waits for it to run.
+// Returns ctx.Err() if ctx is done before fn completes (fn may still run if it was already enqueued),
+// ErrSerialExecutorClosed if the executor was shut down, or an error wrapping a *PanicReport if fn panicked.
+func (se *SerialExecutor) SubmitWait(ctx context.Context, fn func()) error {
+	var task = serialTask{fn: fn, result: make(chan error, 1)}
+
+	if err := se.enqueue(ctx, task); err != nil {
+		return err
+	}
+
+	select {
+	case err := <-task.result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Flush waits until all the functions submitted before the call have run.
+// Returns ctx.Err() if ctx is done first, or ErrSerialExecutorClosed if the executor was shut down.
+func (se *SerialExecutor) Flush(ctx context.Context) error {
+	return se.SubmitWait(ctx, func() {})
+}
+
+// Shutdown stops accepting new functions and waits until the pending ones have run and the executor goroutine exits.
+// Submissions still waiting for room in the queue fail with ErrSerialExecutorClosed.
+// Returns ctx.Err() if ctx is done first; the pending functions still run in the background.
+// Calling Shutdown more than once is safe.
+func (se *SerialExecutor) Shutdown(ctx context.Context) error {
+	se.mu.Lock()
+	if !se.closed {
+		se.closed = true
+		close(se.closing)
+	}
+	se.mu.Unlock()
+
+	select {
+	case <-se.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (se *SerialExecutor) enqueue(ctx context.Context, task serialTask) error {
+	if err := se.beginSend(); err != nil {
+		return err
+	}
+	defer se.sending.Done()
+
+	select {
+	case se.queue <- task:
+		return nil
+	case <-se.closing:
+		return ErrSerialExecutorClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// beginSend registers a send in flight, which must call se.sending.Done when finished,
+// or returns ErrSerialExecutorClosed if the executor was shut down.
+func (se *SerialExecutor) beginSend() error {
+	se.mu.RLock()
+	defer se.mu.RUnlock()
+
+	if se.closed {
+		return ErrSerialExecutorClosed
+	}
+	se.sending.Add(1)
+	return nil
+}
+
+func (se *SerialExecutor) loop() {
+	defer close(se.done)
+	for {
+		select {
+		case task := <-se.queue:
+			task.run()
+		case <-se.closing:
+			se.drain()
+			return
+		}
+	}
+}
+
+// drain runs the pending functions after Shutdown, including the ones of the sends still in flight.
+func (se *SerialExecutor) drain() {
+	var sent = make(chan struct{})
+	go func() {
+		se.sending.Wait()
+		close(sent)
+	}()
+
+	for {
+		select {
+		case task := <-se.queue:
+			task.run()
+		case <-sent:
+			// no more sends: run the functions left in the queue.
+			for len(se.queue) > 0 {
+				task := <-se.queue
+				task.run()
+			}
+			return
+		}
+	}
+}
+
+func (t serialTask) run() {
+	var err = runSerialTask(t.fn)
+	if t.result != nil {
+		t.result <- err
+	}
+}
+
+func runSerialTask(fn func()) error {
+	err := callWithPanicReport(context.Background(), func() error {
+		fn()
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("serial executor task: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package devtoolkit
+
+// Set is a generic set of comparable items.
+// The zero value is an empty set ready to use. A Set is not safe for concurrent use.
+type Set[T comparable] struct {
+	items map[T]struct{}
+}
+
+// NewSet returns a new Set containing the given items.
+func NewSet[T comparable](items ...T) *Set[T] {
+	var s = &Set[T]{items: make(map[T]struct{}, len(items))}
+	s.Add(items...)
+	return s
+}
+
+// Add adds the given items to the set.
+func (s *Set[T]) Add(items ...T) {
+	if s.items == nil {
+		s.items = make(map[T]struct{}, len(items))
+	}
+	for _, item := range items {
+		s.items[item] = struct{}{}
+	}
+}
+
+// Remove removes the given items from the set, if present.
+func (s *Set[T]) Remove(items ...T) {
+	for _, item := range items {
+		delete(s.items, item)
+	}
+}
+
+// Contains returns true if item is in the set.
+func (s *Set[T]) Contains(item T) bool {
+	_, ok := s.items[item]
+	return ok
+}
+
+// Len returns the number of items in the set.
+func (s *Set[T]) Len() int {
+	return len(s.items)
+}
+
+// ToSlice returns the items of the set in no particular order.
+func (s *Set[T]) ToSlice() []T {
+	var items = make([]T, 0, len(s.items))
+	for item := range s.items {
+		items = append(items, item)
+	}
+	return items
+}
+
+// Clone returns a new set with the same items.
+func (s *Set[T]) Clone() *Set[T] {
+	var c = &Set[T]{items: make(map[T]struct{}, len(s.items))}
+	for item := range s.items {
+		c.items[item] = struct{}{}
+	}
+	return c
+}
+
+// Union returns a new set with the items in s or other.
+func (s *Set[T]) Union(other *Set[T]) *Set[T] {
+	var u = s.Clone()
+	for item := range other.items {
+		u.items[item] = struct{}{}
+	}
+	return u
+}
+
+// Intersection returns a new set with the items in both s and other.
+func (s *Set[T]) Intersection(other *Set[T]) *Set[T] {
+	var small, large = s, other
+	if small.Len() > large.Len() {
+		small, large = large, small
+	}
+
+	var i = NewSet[T]()
+	for item := range small.items {
+		if large.Contains(item) {
+			i.items[item] = struct{}{}
+		}
+	}
+	return i
+}
+
+// Difference returns a new set with the items in s that are not in other.
+func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
+	var d = NewSet[T]()
+	for item := range s.items {
+		if !other.Contains(item) {
+			d.items[item] = struct{}{}
+		}
+	}
+	return d
+}
+
+// SymmetricDifference returns a new set with the items in either s or other, but not in both.
+func (s *Set[T]) SymmetricDifference(other *Set[T]) *Set[T] {
+	var d = s.Difference(other)
+	for item := range other.items {
+		if !s.Contains(item) {
+			d.items[item] = struct{}{}
+		}
+	}
+	return d
+}
+
+// IsSubsetOf returns true if all the items of s are in other.
+func (s *Set[T]) IsSubsetOf(other *Set[T]) bool {
+	if s.Len() > other.Len() {
+		return false
+	}
+	for item := range s.items {
+		if !other.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal returns true if s and other contain the same items.
+func (s *Set[T]) Equal(other *Set[T]) bool {
+	return s.Len() == other.Len() && s.IsSubsetOf(other)
+}
@@ -1,5 +1,33 @@
 package devtoolkit
 
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"golang.org/x/exp/constraints"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ErrDuplicateKey is returned by AssociateWithPolicy, AssociateByWithPolicy and TransformKeys when two items
+// have the same key and the policy is DuplicateKeyError.
+var ErrDuplicateKey = errors.New("duplicate key")
+
+// DuplicateKeyPolicy defines how duplicate keys are handled when building a map from a slice or another map.
+type DuplicateKeyPolicy int
+
+const (
+	// DuplicateKeyKeepLast keeps the value of the last item with the key.
+	DuplicateKeyKeepLast DuplicateKeyPolicy = iota
+
+	// DuplicateKeyKeepFirst keeps the value of the first item with the key.
+	DuplicateKeyKeepFirst
+
+	// DuplicateKeyError fails with ErrDuplicateKey.
+	DuplicateKeyError
+)
+
 // Contains checks if a slice contains an item. Item must be comparable.
 func Contains[T comparable](slice []T, item T) bool {
 	for _, s := range slice {
@@ -63,88 +91,99 @@ func LastIndexOfWithPredicate[T any](slice []T, item T, predicate func(T, T) boo
 }
 
 // Remove removes the first instance of item from slice, if present.
-// Returns true if item was removed, false otherwise.
-func Remove[T comparable](slice []T, item T) bool {
-	for i, s := range slice {
-		if s == item {
-			slice = append(slice[:i], slice[i+1:]...)
-			return true
-		}
+// Returns the resulting slice and true if item was removed, false otherwise.
+// The removal is done in place: the returned slice shares the backing array of slice,
+// and the elements between the new and the original length are zeroed.
+func Remove[T comparable](slice []T, item T) ([]T, bool) {
+	if i := IndexOf(slice, item); i >= 0 {
+		return slices.Delete(slice, i, i+1), true
 	}
-	return false
+	return slice, false
 }
 
 // RemoveWithPredicate removes the first instance of item from slice, if present.
 // Use predicate to compare items.
-// Returns true if item was removed, false otherwise.
-func RemoveWithPredicate[T any](slice []T, item T, predicate func(T, T) bool) bool {
-	for i, s := range slice {
-		if predicate(s, item) {
-			slice = append(slice[:i], slice[i+1:]...)
-			return true
-		}
+// Returns the resulting slice and true if item was removed, false otherwise.
+// The removal is done in place, see Remove.
+func RemoveWithPredicate[T any](slice []T, item T, predicate func(T, T) bool) ([]T, bool) {
+	if i := IndexOfWithPredicate(slice, item, predicate); i >= 0 {
+		return slices.Delete(slice, i, i+1), true
 	}
-	return false
+	return slice, false
 }
 
 // RemoveAll removes all instances of item from slice, if present.
-// Returns true if item was removed, false otherwise.
-func RemoveAll[T comparable](slice []T, item T) bool {
-	var removed bool
-	for i, s := range slice {
-		if s == item {
-			slice = append(slice[:i], slice[i+1:]...)
-			removed = true
-		}
-	}
-	return removed
+// Returns the resulting slice and true if item was removed, false otherwise.
+// The removal is done in place, see Remove.
+func RemoveAll[T comparable](slice []T, item T) ([]T, bool) {
+	return RemoveIf(slice, func(s T) bool {
+		return s == item
+	})
 }
 
 // RemoveAllWithPredicate removes all instances of item from slice, if present.
 // Use predicate to compare items.
-// Returns true if item was removed, false otherwise.
-func RemoveAllWithPredicate[T any](slice []T, item T, predicate func(T, T) bool) bool {
-	var removed bool
-	for i, s := range slice {
-		if predicate(s, item) {
-			slice = append(slice[:i], slice[i+1:]...)
-			removed = true
-		}
-	}
-	return removed
+// Returns the resulting slice and true if item was removed, false otherwise.
+// The removal is done in place, see Remove.
+func RemoveAllWithPredicate[T any](slice []T, item T, predicate func(T, T) bool) ([]T, bool) {
+	return RemoveIf(slice, func(s T) bool {
+		return predicate(s, item)
+	})
 }
 
 // RemoveAt removes the item at the given index from slice.
-// Returns true if item was removed, false otherwise.
-func RemoveAt[T any](slice []T, index int) bool {
+// Returns the resulting slice and true if item was removed, false otherwise.
+// The removal is done in place, see Remove.
+func RemoveAt[T any](slice []T, index int) ([]T, bool) {
 	if index < 0 || index >= len(slice) {
-		return false
+		return slice, false
 	}
-	slice = append(slice[:index], slice[index+1:]...)
-	return true
+	return slices.Delete(slice, index, index+1), true
 }
 
-// RemoveRange removes the items in the given range from slice.
-// Returns true if items were removed, false otherwise.
-func RemoveRange[T any](slice []T, start, end int) bool {
+// RemoveRange removes the items in the given range from slice, both start and end included.
+// Returns the resulting slice and true if items were removed, false otherwise.
+// The removal is done in place, see Remove.
+func RemoveRange[T any](slice []T, start, end int) ([]T, bool) {
 	if start < 0 || end < 0 || start >= len(slice) || end >= len(slice) || start > end {
-		return false
+		return slice, false
 	}
-	slice = append(slice[:start], slice[end+1:]...)
-	return true
+	return slices.Delete(slice, start, end+1), true
 }
 
 // RemoveIf removes all items from slice for which predicate returns true.
-// Returns true if items were removed, false otherwise.
-func RemoveIf[T any](slice []T, predicate func(T) bool) bool {
-	var removed bool
-	for i := 0; i < len(slice); i++ {
-		if predicate(slice[i]) {
-			slice = append(slice[:i], slice[i+1:]...)
-			removed = true
-		}
+// Returns the resulting slice and true if items were removed, false otherwise.
+// The removal is done in place, see Remove.
+func RemoveIf[T any](slice []T, predicate func(T) bool) ([]T, bool) {
+	var originalLen = len(slice)
+	slice = slices.DeleteFunc(slice, predicate)
+	return slice, len(slice) < originalLen
+}
+
+// Insert inserts item at the given index of slice, shifting the following items.
+// index may be len(slice) to append the item.
+// Returns the resulting slice and true if item was inserted, false if index is out of range.
+// The insertion is done in place, see InsertAt.
+func Insert[T any](slice []T, index int, item T) ([]T, bool) {
+	return InsertAt(slice, index, item)
+}
+
+// InsertAt inserts items at the given index of slice, shifting the following items.
+// index may be len(slice) to append the items.
+// Returns the resulting slice and true if items were inserted, false if index is out of range.
+// The insertion is done in place when slice has enough capacity, so the returned slice may share
+// the backing array of slice; otherwise a new array is allocated.
+func InsertAt[T any](slice []T, index int, items ...T) ([]T, bool) {
+	if index < 0 || index > len(slice) {
+		return slice, false
 	}
-	return removed
+	return slices.Insert(slice, index, items...), true
+}
+
+// Prepend inserts items at the beginning of slice and returns the resulting slice.
+// The insertion is done in place when slice has enough capacity, see InsertAt.
+func Prepend[T any](slice []T, items ...T) []T {
+	return slices.Insert(slice, 0, items...)
 }
 
 // Filter returns a new slice containing all items from slice for which predicate returns true.
@@ -169,6 +208,31 @@ func FilterNot[T any](slice []T, predicate func(T) bool) []T {
 	return filtered
 }
 
+// Compact returns a new slice containing the items of slice that are not the zero value of T.
+func Compact[T comparable](slice []T) []T {
+	var zero T
+	return Filter(slice, func(t T) bool { return t != zero })
+}
+
+// CompactNil returns a new slice containing the non-nil pointers of slice.
+func CompactNil[T any](slice []*T) []*T {
+	return Filter(slice, func(t *T) bool { return t != nil })
+}
+
+// DerefSlice returns a new slice with the values pointed by the items of slice,
+// using defaultVal for nil pointers.
+func DerefSlice[T any](slice []*T, defaultVal T) []T {
+	var values = make([]T, len(slice))
+	for i, p := range slice {
+		if p == nil {
+			values[i] = defaultVal
+		} else {
+			values[i] = *p
+		}
+	}
+	return values
+}
+
 // Map returns a new slice containing the results of applying the given mapper function to each item in slice.
 func Map[T, R any](slice []T, mapper func(T) R) []R {
 	var mapped []R
@@ -178,20 +242,18 @@ func Map[T, R any](slice []T, mapper func(T) R) []R {
 	return mapped
 }
 
-// RemoveDuplicates removes all duplicate items from slice.
-// Returns true if items were removed, false otherwise.
-func RemoveDuplicates[T comparable](slice []T) bool {
-	var counter = make(map[T]bool)
-	var removed bool
-	for i := 0; i < len(slice); i++ {
-		if counter[slice[i]] {
-			slice = append(slice[:i], slice[i+1:]...)
-			removed = true
-		} else {
-			counter[slice[i]] = true
+// RemoveDuplicates removes all duplicate items from slice, keeping the first instance of each item.
+// Returns the resulting slice and true if items were removed, false otherwise.
+// The removal is done in place, see Remove. Use Distinct to get a new slice instead.
+func RemoveDuplicates[T comparable](slice []T) ([]T, bool) {
+	var seen = make(map[T]struct{}, len(slice))
+	return RemoveIf(slice, func(s T) bool {
+		if _, ok := seen[s]; ok {
+			return true
 		}
-	}
-	return removed
+		seen[s] = struct{}{}
+		return false
+	})
 }
 
 // Reverse reverses the order of items in slice.
@@ -201,6 +263,54 @@ func Reverse[T any](slice []T) {
 	}
 }
 
+// Rotate rotates the items of slice in place by n positions.
+// A positive n rotates to the left (the first n items move to the end),
+// a negative n rotates to the right (the last -n items move to the beginning).
+// n may be greater than len(slice).
+func Rotate[T any](slice []T, n int) {
+	if len(slice) == 0 {
+		return
+	}
+
+	n %= len(slice)
+	if n < 0 {
+		n += len(slice)
+	}
+	if n == 0 {
+		return
+	}
+
+	Reverse(slice[:n])
+	Reverse(slice[n:])
+	Reverse(slice)
+}
+
+// Swap swaps the items at indexes i and j of slice.
+// Returns true if the items were swapped, false if any index is out of range.
+func Swap[T any](slice []T, i, j int) bool {
+	if i < 0 || j < 0 || i >= len(slice) || j >= len(slice) {
+		return false
+	}
+	slice[i], slice[j] = slice[j], slice[i]
+	return true
+}
+
+// SwapRange swaps the 'length' items starting at index i with the 'length' items starting at index j.
+// Returns true if the ranges were swapped, false if they are out of range or overlap.
+func SwapRange[T any](slice []T, i, j, length int) bool {
+	if i < 0 || j < 0 || length < 0 || i > len(slice) || j > len(slice) || length > len(slice)-i || length > len(slice)-j {
+		return false
+	}
+	if i != j && max(i, j) < min(i, j)+length {
+		return false
+	}
+
+	for k := 0; k < length; k++ {
+		slice[i+k], slice[j+k] = slice[j+k], slice[i+k]
+	}
+	return true
+}
+
 // Difference returns a new slice containing all items from slice that are not present in other.
 func Difference[T comparable](slice, other []T) []T {
 	var set = make(map[T]bool)
@@ -233,6 +343,66 @@ func Intersection[T comparable](slice, other []T) []T {
 	return inter
 }
 
+// DifferenceWithPredicate returns a new slice containing all items from slice that are not present in other.
+// Use predicate to compare items.
+func DifferenceWithPredicate[T any](slice, other []T, predicate func(T, T) bool) []T {
+	var diff []T
+	for _, s := range slice {
+		if !ContainsWithPredicate(other, s, predicate) {
+			diff = append(diff, s)
+		}
+	}
+	return diff
+}
+
+// IntersectionWithPredicate returns a new slice containing all items from slice that are also present in other.
+// Use predicate to compare items.
+func IntersectionWithPredicate[T any](slice, other []T, predicate func(T, T) bool) []T {
+	var inter []T
+	for _, s := range slice {
+		if ContainsWithPredicate(other, s, predicate) {
+			inter = append(inter, s)
+		}
+	}
+	return inter
+}
+
+// DifferenceBy returns a new slice containing all items from slice whose key, as returned by keyFn,
+// is not the key of any item in other.
+func DifferenceBy[T any, K comparable](slice, other []T, keyFn func(T) K) []T {
+	var set = keySet(other, keyFn)
+
+	var diff []T
+	for _, s := range slice {
+		if !set[keyFn(s)] {
+			diff = append(diff, s)
+		}
+	}
+	return diff
+}
+
+// IntersectionBy returns a new slice containing all items from slice whose key, as returned by keyFn,
+// is also the key of an item in other.
+func IntersectionBy[T any, K comparable](slice, other []T, keyFn func(T) K) []T {
+	var set = keySet(other, keyFn)
+
+	var inter []T
+	for _, s := range slice {
+		if set[keyFn(s)] {
+			inter = append(inter, s)
+		}
+	}
+	return inter
+}
+
+func keySet[T any, K comparable](slice []T, keyFn func(T) K) map[K]bool {
+	var set = make(map[K]bool, len(slice))
+	for _, s := range slice {
+		set[keyFn(s)] = true
+	}
+	return set
+}
+
 // Union returns a new slice containing all items from slice and other.
 func Union[T comparable](slice, other []T) []T {
 	var set = make(map[T]bool)
@@ -250,13 +420,66 @@ func Union[T comparable](slice, other []T) []T {
 	return union
 }
 
+// JoinOptions holds options for JoinToString.
+type JoinOptions struct {
+	// Prefix is written before the joined items.
+	Prefix string
+
+	// Suffix is written after the joined items.
+	Suffix string
+
+	// Limit is the maximum number of items to join, all items are joined if it is not positive.
+	Limit int
+
+	// Truncated returns the text written in place of the items beyond Limit, after the separator.
+	// Defaults to "and <remaining> more".
+	Truncated func(remaining int) string
+}
+
+// JoinToString joins the items of slice formatted with format, separated by sep, in a single allocation pass.
+// If format is nil, items are formatted with fmt.Sprint.
+func JoinToString[T any](slice []T, sep string, format func(T) string, optFns ...func(*JoinOptions)) string {
+	var opts = &JoinOptions{
+		Truncated: func(remaining int) string {
+			return fmt.Sprintf("and %d more", remaining)
+		},
+	}
+	for _, fn := range optFns {
+		fn(opts)
+	}
+
+	if format == nil {
+		format = func(t T) string { return fmt.Sprint(t) }
+	}
+
+	var items = slice
+	if opts.Limit > 0 && len(slice) > opts.Limit {
+		items = slice[:opts.Limit]
+	}
+
+	var sb strings.Builder
+	sb.WriteString(opts.Prefix)
+	for i, s := range items {
+		if i > 0 {
+			sb.WriteString(sep)
+		}
+		sb.WriteString(format(s))
+	}
+	if remaining := len(slice) - len(items); remaining > 0 {
+		if len(items) > 0 {
+			sb.WriteString(sep)
+		}
+		sb.WriteString(opts.Truncated(remaining))
+	}
+	sb.WriteString(opts.Suffix)
+	return sb.String()
+}
+
 // GetMapKeys returns a new slice containing all keys from the given map.
+//
+// Deprecated: use MapKeys.
 func GetMapKeys[K comparable, V any](m map[K]V) []K {
-	var keys []K
-	for k := range m {
-		keys = append(keys, k)
-	}
-	return keys
+	return MapKeys(m)
 }
 
 // GetMapValues returns a new slice containing all values from the given map.
@@ -272,3 +495,460 @@ func GetMapValues[K comparable, V comparable](m map[K]V, removeDuplicates bool)
 	}
 	return values
 }
+
+// Chunk splits slice into consecutive chunks of the given size. The last chunk may contain fewer items.
+// Chunks share the backing array of slice but are capped, so appending to a chunk never overwrites the next one.
+// Returns nil if size is not positive or slice is empty.
+func Chunk[T any](slice []T, size int) [][]T {
+	if size <= 0 || len(slice) == 0 {
+		return nil
+	}
+
+	var chunks = make([][]T, 0, (len(slice)+size-1)/size)
+	for i := 0; i < len(slice); i += size {
+		end := min(i+size, len(slice))
+		chunks = append(chunks, slice[i:end:end])
+	}
+	return chunks
+}
+
+// GroupBy groups the items of slice by the key returned by keyFn.
+// The order of items within each group is preserved.
+func GroupBy[T any, K comparable](slice []T, keyFn func(T) K) map[K][]T {
+	var grouped = make(map[K][]T)
+	for _, s := range slice {
+		key := keyFn(s)
+		grouped[key] = append(grouped[key], s)
+	}
+	return grouped
+}
+
+// GroupByAndMap groups the items of slice by the key returned by keyFn, transforming each item with mapper.
+// The order of items within each group is preserved.
+func GroupByAndMap[T any, K comparable, V any](slice []T, keyFn func(T) K, mapper func(T) V) map[K][]V {
+	var grouped = make(map[K][]V)
+	for _, s := range slice {
+		key := keyFn(s)
+		grouped[key] = append(grouped[key], mapper(s))
+	}
+	return grouped
+}
+
+// CountBy returns the number of items of slice for each key returned by keyFn.
+func CountBy[T any, K comparable](slice []T, keyFn func(T) K) map[K]int {
+	var counts = make(map[K]int)
+	for _, s := range slice {
+		counts[keyFn(s)]++
+	}
+	return counts
+}
+
+// Frequencies returns the number of occurrences of each item of slice.
+func Frequencies[T comparable](slice []T) map[T]int {
+	return CountBy(slice, func(t T) T { return t })
+}
+
+// Associate returns a map built from the key-value pairs returned by fn for each item of slice.
+// If several items have the same key, the value of the last one is kept.
+func Associate[T any, K comparable, V any](slice []T, fn func(T) (K, V)) map[K]V {
+	m, _ := AssociateWithPolicy(slice, fn, DuplicateKeyKeepLast)
+	return m
+}
+
+// AssociateBy returns a map from the key returned by keyFn to each item of slice.
+// If several items have the same key, the last one is kept.
+func AssociateBy[T any, K comparable](slice []T, keyFn func(T) K) map[K]T {
+	m, _ := AssociateByWithPolicy(slice, keyFn, DuplicateKeyKeepLast)
+	return m
+}
+
+// AssociateWithPolicy returns a map built from the key-value pairs returned by fn for each item of slice,
+// handling duplicate keys according to policy.
+// Returns an error wrapping ErrDuplicateKey if policy is DuplicateKeyError and a key is repeated.
+func AssociateWithPolicy[T any, K comparable, V any](slice []T, fn func(T) (K, V), policy DuplicateKeyPolicy) (map[K]V, error) {
+	var m = make(map[K]V, len(slice))
+	for _, s := range slice {
+		key, value := fn(s)
+		if _, ok := m[key]; ok {
+			switch policy {
+			case DuplicateKeyKeepFirst:
+				continue
+			case DuplicateKeyError:
+				return nil, fmt.Errorf("%w: %v", ErrDuplicateKey, key)
+			}
+		}
+		m[key] = value
+	}
+	return m, nil
+}
+
+// AssociateByWithPolicy returns a map from the key returned by keyFn to each item of slice,
+// handling duplicate keys according to policy.
+// Returns an error wrapping ErrDuplicateKey if policy is DuplicateKeyError and a key is repeated.
+func AssociateByWithPolicy[T any, K comparable](slice []T, keyFn func(T) K, policy DuplicateKeyPolicy) (map[K]T, error) {
+	return AssociateWithPolicy(slice, func(t T) (K, T) { return keyFn(t), t }, policy)
+}
+
+// Partition splits slice in a single pass into the items for which predicate returns true (matched)
+// and the items for which it returns false (unmatched). The order of items is preserved.
+func Partition[T any](slice []T, predicate func(T) bool) (matched, unmatched []T) {
+	for _, s := range slice {
+		if predicate(s) {
+			matched = append(matched, s)
+		} else {
+			unmatched = append(unmatched, s)
+		}
+	}
+	return matched, unmatched
+}
+
+// Reduce folds slice from left to right, applying fn to the accumulated value and each item, starting with initial.
+func Reduce[T, R any](slice []T, initial R, fn func(R, T) R) R {
+	var acc = initial
+	for _, s := range slice {
+		acc = fn(acc, s)
+	}
+	return acc
+}
+
+// ReduceRight folds slice from right to left, applying fn to the accumulated value and each item, starting with initial.
+func ReduceRight[T, R any](slice []T, initial R, fn func(R, T) R) R {
+	var acc = initial
+	for i := len(slice) - 1; i >= 0; i-- {
+		acc = fn(acc, slice[i])
+	}
+	return acc
+}
+
+// Flatten returns a new slice containing the items of all the given slices, in order.
+func Flatten[T any](nested [][]T) []T {
+	var total int
+	for _, s := range nested {
+		total += len(s)
+	}
+
+	var flattened = make([]T, 0, total)
+	for _, s := range nested {
+		flattened = append(flattened, s...)
+	}
+	return flattened
+}
+
+// FlatMap returns a new slice containing the concatenated results of applying the given mapper function to each item in slice.
+func FlatMap[T, R any](slice []T, mapper func(T) []R) []R {
+	var mapped []R
+	for _, s := range slice {
+		mapped = append(mapped, mapper(s)...)
+	}
+	return mapped
+}
+
+// Zip returns a new slice of pairs combining the items of first and second at the same index.
+// If the slices have different lengths, the result has the length of the shorter one.
+func Zip[F, S any](first []F, second []S) []Pair[F, S] {
+	var size = min(len(first), len(second))
+	var zipped = make([]Pair[F, S], size)
+	for i := 0; i < size; i++ {
+		zipped[i] = NewPair(first[i], second[i])
+	}
+	return zipped
+}
+
+// Unzip splits a slice of pairs into a slice with the first values and a slice with the second values.
+func Unzip[F, S any](pairs []Pair[F, S]) ([]F, []S) {
+	var first = make([]F, len(pairs))
+	var second = make([]S, len(pairs))
+	for i, p := range pairs {
+		first[i], second[i] = p.First, p.Second
+	}
+	return first, second
+}
+
+// SortBy sorts slice in place using the given less function. The sort is not guaranteed to be stable.
+func SortBy[T any](slice []T, less func(a, b T) bool) {
+	sort.Slice(slice, func(i, j int) bool {
+		return less(slice[i], slice[j])
+	})
+}
+
+// SortStableBy sorts slice in place using the given less function, keeping the original order of equal items.
+func SortStableBy[T any](slice []T, less func(a, b T) bool) {
+	sort.SliceStable(slice, func(i, j int) bool {
+		return less(slice[i], slice[j])
+	})
+}
+
+// SortByKey sorts slice in place in ascending order of the key returned by keyFn, keeping the original order of equal items.
+func SortByKey[T any, K constraints.Ordered](slice []T, keyFn func(T) K) {
+	sort.SliceStable(slice, func(i, j int) bool {
+		return keyFn(slice[i]) < keyFn(slice[j])
+	})
+}
+
+// BinarySearch searches for target in slice, which must be sorted in ascending order.
+// Returns the position where target is found, or the position where it would be inserted,
+// and true if target was found.
+func BinarySearch[T constraints.Ordered](slice []T, target T) (int, bool) {
+	return slices.BinarySearch(slice, target)
+}
+
+// BinarySearchBy searches for target in slice, which must be sorted in the order defined by cmp.
+// cmp must return a negative number if a < b, a positive number if a > b and zero if they are equal.
+// Returns the position where target is found, or the position where it would be inserted,
+// and true if target was found.
+func BinarySearchBy[T any](slice []T, target T, cmp func(a, b T) int) (int, bool) {
+	return slices.BinarySearchFunc(slice, target, cmp)
+}
+
+// SortedInsert inserts item into slice, which must be sorted in ascending order, keeping it sorted.
+// item is inserted after any equal items. Returns the resulting slice, see InsertAt for aliasing.
+func SortedInsert[T constraints.Ordered](slice []T, item T) []T {
+	return SortedInsertBy(slice, item, func(a, b T) int {
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	})
+}
+
+// SortedInsertBy inserts item into slice, which must be sorted in the order defined by cmp, keeping it sorted.
+// item is inserted after any equal items. Returns the resulting slice, see InsertAt for aliasing.
+func SortedInsertBy[T any](slice []T, item T, cmp func(a, b T) int) []T {
+	i := sort.Search(len(slice), func(i int) bool {
+		return cmp(slice[i], item) > 0
+	})
+	return slices.Insert(slice, i, item)
+}
+
+// SumBy returns the sum of the values returned by fn for each item in slice.
+func SumBy[T any, N Number](slice []T, fn func(T) N) N {
+	var sum N
+	for _, s := range slice {
+		sum += fn(s)
+	}
+	return sum
+}
+
+// MinBy returns the item of slice with the lowest value returned by fn.
+// If several items share the lowest value, the first one is returned.
+// Returns false if slice is empty.
+func MinBy[T any, N Number](slice []T, fn func(T) N) (T, bool) {
+	return selectBy(slice, fn, func(a, b N) bool { return a < b })
+}
+
+// MaxBy returns the item of slice with the highest value returned by fn.
+// If several items share the highest value, the first one is returned.
+// Returns false if slice is empty.
+func MaxBy[T any, N Number](slice []T, fn func(T) N) (T, bool) {
+	return selectBy(slice, fn, func(a, b N) bool { return a > b })
+}
+
+// AvgBy returns the average of the values returned by fn for each item in slice.
+// Returns false if slice is empty.
+func AvgBy[T any, N Number](slice []T, fn func(T) N) (float64, bool) {
+	if len(slice) == 0 {
+		return 0, false
+	}
+
+	var sum float64
+	for _, s := range slice {
+		sum += float64(fn(s))
+	}
+	return sum / float64(len(slice)), true
+}
+
+// TopN returns the n greatest items of slice in the order defined by less, from the greatest to the smallest.
+// It keeps a heap of at most n items instead of sorting slice, running in O(len(slice) log n).
+// If several items are equal, the first ones are kept, in no particular order. Returns nil if n is not positive.
+func TopN[T any](slice []T, n int, less func(a, b T) bool) []T {
+	if n <= 0 {
+		return nil
+	}
+
+	// min-heap of the greatest items seen so far, with the smallest of them at the root
+	var h = &priorityHeap[T]{items: make([]T, 0, min(n, len(slice))), less: less}
+	for _, s := range slice {
+		switch {
+		case h.Len() < n:
+			heap.Push(h, s)
+		case less(h.items[0], s):
+			h.items[0] = s
+			heap.Fix(h, 0)
+		}
+	}
+
+	var top = make([]T, h.Len())
+	for i := len(top) - 1; i >= 0; i-- {
+		top[i] = heap.Pop(h).(T)
+	}
+	return top
+}
+
+func selectBy[T any, N Number](slice []T, fn func(T) N, better func(a, b N) bool) (T, bool) {
+	if len(slice) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	selected, selectedValue := slice[0], fn(slice[0])
+	for _, s := range slice[1:] {
+		if v := fn(s); better(v, selectedValue) {
+			selected, selectedValue = s, v
+		}
+	}
+	return selected, true
+}
+
+// Any returns true if predicate returns true for at least one item in slice.
+func Any[T any](slice []T, predicate func(T) bool) bool {
+	for _, s := range slice {
+		if predicate(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// All returns true if predicate returns true for every item in slice. Returns true for an empty slice.
+func All[T any](slice []T, predicate func(T) bool) bool {
+	for _, s := range slice {
+		if !predicate(s) {
+			return false
+		}
+	}
+	return true
+}
+
+// None returns true if predicate returns false for every item in slice. Returns true for an empty slice.
+func None[T any](slice []T, predicate func(T) bool) bool {
+	return !Any(slice, predicate)
+}
+
+// Count returns the number of items in slice for which predicate returns true.
+func Count[T any](slice []T, predicate func(T) bool) int {
+	var count int
+	for _, s := range slice {
+		if predicate(s) {
+			count++
+		}
+	}
+	return count
+}
+
+// Find returns the first item in slice for which predicate returns true.
+// Returns false if no item matches.
+func Find[T any](slice []T, predicate func(T) bool) (T, bool) {
+	for _, s := range slice {
+		if predicate(s) {
+			return s, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// FindLast returns the last item in slice for which predicate returns true.
+// Returns false if no item matches.
+func FindLast[T any](slice []T, predicate func(T) bool) (T, bool) {
+	for i := len(slice) - 1; i >= 0; i-- {
+		if predicate(slice[i]) {
+			return slice[i], true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// Distinct returns a new slice containing the items of slice without duplicates, preserving the first-seen order.
+func Distinct[T comparable](slice []T) []T {
+	return DistinctBy(slice, func(t T) T { return t })
+}
+
+// DistinctBy returns a new slice containing the items of slice with a unique key returned by keyFn,
+// preserving the first item seen for each key.
+func DistinctBy[T any, K comparable](slice []T, keyFn func(T) K) []T {
+	var seen = make(map[K]struct{}, len(slice))
+	var distinct []T
+	for _, s := range slice {
+		key := keyFn(s)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		distinct = append(distinct, s)
+	}
+	return distinct
+}
+
+// Windows returns the sliding windows of the given size over slice, moving step items between windows.
+// Only full windows are returned. Windows share the backing array of slice but are capped, so appending
+// to a window never overwrites the following items.
+// Returns nil if size or step are not positive, or slice has fewer items than size.
+func Windows[T any](slice []T, size, step int) [][]T {
+	if size <= 0 || step <= 0 || len(slice) < size {
+		return nil
+	}
+
+	var count = (len(slice)-size)/step + 1
+	var windows = make([][]T, count)
+	for k := 0; k < count; k++ {
+		i := k * step
+		windows[k] = slice[i : i+size : i+size]
+	}
+	return windows
+}
+
+// Equal returns true if both slices have the same length and the same items in the same order.
+// A nil slice and an empty slice are considered equal.
+func Equal[T comparable](a, b []T) bool {
+	return EqualBy(a, b, func(x, y T) bool { return x == y })
+}
+
+// EqualBy returns true if both slices have the same length and eq returns true for each pair of items
+// at the same position.
+func EqualBy[T any](a, b []T, eq func(T, T) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !eq(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// EqualUnordered returns true if both slices contain the same items with the same number of occurrences,
+// regardless of their order.
+func EqualUnordered[T comparable](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	var counts = make(map[T]int, len(a))
+	for _, s := range a {
+		counts[s]++
+	}
+	for _, s := range b {
+		if counts[s] == 0 {
+			return false
+		}
+		counts[s]--
+	}
+	return true
+}
+
+// PrefixOf returns true if the items of prefix are the first items of s, in the same order.
+// An empty prefix is a prefix of any slice.
+func PrefixOf[T comparable](prefix, s []T) bool {
+	return len(prefix) <= len(s) && Equal(prefix, s[:len(prefix)])
+}
+
+// SuffixOf returns true if the items of suffix are the last items of s, in the same order.
+// An empty suffix is a suffix of any slice.
+func SuffixOf[T comparable](suffix, s []T) bool {
+	return len(suffix) <= len(s) && Equal(suffix, s[len(s)-len(suffix):])
+}
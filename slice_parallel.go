@@ -0,0 +1,95 @@
+package devtoolkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+)
+
+// ParallelMap applies mapper to each item of slice using up to 'workers' concurrent goroutines
+// (runtime.NumCPU() if workers is not positive) and returns the results in input order.
+// All items are processed even if some fail; the errors are joined, each one prefixed with its item index.
+// If ctx is done, the remaining items are not started and ctx.Err() is included in the returned error.
+// The results slice is always returned; failed or skipped items hold the zero value of R.
+func ParallelMap[T, R any](ctx context.Context, slice []T, workers int, mapper func(context.Context, T) (R, error)) ([]R, error) {
+	if ctx == nil {
+		return nil, ConcurrentExecNilContextErr
+	}
+
+	var results = make([]R, len(slice))
+	err := parallelRun(ctx, len(slice), workers, func(i int) error {
+		r, err := mapper(ctx, slice[i])
+		results[i] = r
+		return err
+	})
+	return results, err
+}
+
+// ParallelForEach calls fn for each item of slice using up to 'workers' concurrent goroutines
+// (runtime.NumCPU() if workers is not positive).
+// All items are processed even if some fail; the errors are joined, each one prefixed with its item index.
+// If ctx is done, the remaining items are not started and ctx.Err() is included in the returned error.
+func ParallelForEach[T any](ctx context.Context, slice []T, workers int, fn func(context.Context, T) error) error {
+	if ctx == nil {
+		return ConcurrentExecNilContextErr
+	}
+
+	return parallelRun(ctx, len(slice), workers, func(i int) error {
+		return fn(ctx, slice[i])
+	})
+}
+
+// ParallelFilter returns the items of slice for which predicate returns true, in input order,
+// evaluating the predicate with up to 'workers' concurrent goroutines (runtime.NumCPU() if workers is not positive).
+// Items whose predicate fails are excluded; the errors are joined, each one prefixed with its item index.
+// If ctx is done, the remaining items are not evaluated and ctx.Err() is included in the returned error.
+func ParallelFilter[T any](ctx context.Context, slice []T, workers int, predicate func(context.Context, T) (bool, error)) ([]T, error) {
+	if ctx == nil {
+		return nil, ConcurrentExecNilContextErr
+	}
+
+	var keep = make([]bool, len(slice))
+	err := parallelRun(ctx, len(slice), workers, func(i int) error {
+		ok, err := predicate(ctx, slice[i])
+		keep[i] = ok && err == nil
+		return err
+	})
+
+	var filtered []T
+	for i, s := range slice {
+		if keep[i] {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered, err
+}
+
+// parallelRun executes fn for each index in [0, total) using ConcurrentWorkers.
+// A panic in fn is recovered and reported as the error of its item, wrapping a *PanicReport.
+func parallelRun(ctx context.Context, total, workers int, fn func(i int) error) error {
+	if total == 0 {
+		return nil
+	}
+
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	var errs = make([]error, total)
+	var cw = NewConcurrentWorkers(min(workers, total))
+	var ctxErr error
+	for i := 0; i < total; i++ {
+		if ctxErr = ctx.Err(); ctxErr != nil {
+			break
+		}
+		cw.Execute(func() {
+			if err := callWithPanicReport(ctx, func() error { return fn(i) }); err != nil {
+				errs[i] = fmt.Errorf("item %d: %w", i, err)
+			}
+		})
+	}
+	cw.Wait()
+
+	return errors.Join(append([]error{ctxErr}, errs...)...)
+}
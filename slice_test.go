@@ -0,0 +1,167 @@
+package devtoolkit
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRemoveAliasing(t *testing.T) {
+	tests := []struct {
+		name     string
+		remove   func([]int) ([]int, bool)
+		want     []int
+		wantOK   bool
+		wantOrig []int
+	}{
+		{
+			name:     "Remove first instance",
+			remove:   func(s []int) ([]int, bool) { return Remove(s, 2) },
+			want:     []int{1, 3, 2, 4},
+			wantOK:   true,
+			wantOrig: []int{1, 3, 2, 4, 0},
+		},
+		{
+			name:     "Remove missing item",
+			remove:   func(s []int) ([]int, bool) { return Remove(s, 9) },
+			want:     []int{1, 2, 3, 2, 4},
+			wantOK:   false,
+			wantOrig: []int{1, 2, 3, 2, 4},
+		},
+		{
+			name:     "RemoveAll",
+			remove:   func(s []int) ([]int, bool) { return RemoveAll(s, 2) },
+			want:     []int{1, 3, 4},
+			wantOK:   true,
+			wantOrig: []int{1, 3, 4, 0, 0},
+		},
+		{
+			name:     "RemoveAt",
+			remove:   func(s []int) ([]int, bool) { return RemoveAt(s, 0) },
+			want:     []int{2, 3, 2, 4},
+			wantOK:   true,
+			wantOrig: []int{2, 3, 2, 4, 0},
+		},
+		{
+			name:     "RemoveAt negative index",
+			remove:   func(s []int) ([]int, bool) { return RemoveAt(s, -1) },
+			want:     []int{1, 2, 3, 2, 4},
+			wantOK:   false,
+			wantOrig: []int{1, 2, 3, 2, 4},
+		},
+		{
+			name:     "RemoveAt index out of range",
+			remove:   func(s []int) ([]int, bool) { return RemoveAt(s, 5) },
+			want:     []int{1, 2, 3, 2, 4},
+			wantOK:   false,
+			wantOrig: []int{1, 2, 3, 2, 4},
+		},
+		{
+			name:     "RemoveRange",
+			remove:   func(s []int) ([]int, bool) { return RemoveRange(s, 1, 2) },
+			want:     []int{1, 2, 4},
+			wantOK:   true,
+			wantOrig: []int{1, 2, 4, 0, 0},
+		},
+		{
+			name:     "RemoveRange single item",
+			remove:   func(s []int) ([]int, bool) { return RemoveRange(s, 4, 4) },
+			want:     []int{1, 2, 3, 2},
+			wantOK:   true,
+			wantOrig: []int{1, 2, 3, 2, 0},
+		},
+		{
+			name:     "RemoveRange end out of range",
+			remove:   func(s []int) ([]int, bool) { return RemoveRange(s, 1, 5) },
+			want:     []int{1, 2, 3, 2, 4},
+			wantOK:   false,
+			wantOrig: []int{1, 2, 3, 2, 4},
+		},
+		{
+			name:     "RemoveRange negative start",
+			remove:   func(s []int) ([]int, bool) { return RemoveRange(s, -1, 2) },
+			want:     []int{1, 2, 3, 2, 4},
+			wantOK:   false,
+			wantOrig: []int{1, 2, 3, 2, 4},
+		},
+		{
+			name:     "RemoveRange start after end",
+			remove:   func(s []int) ([]int, bool) { return RemoveRange(s, 3, 1) },
+			want:     []int{1, 2, 3, 2, 4},
+			wantOK:   false,
+			wantOrig: []int{1, 2, 3, 2, 4},
+		},
+		{
+			name:     "RemoveIf",
+			remove:   func(s []int) ([]int, bool) { return RemoveIf(s, func(i int) bool { return i%2 == 0 }) },
+			want:     []int{1, 3},
+			wantOK:   true,
+			wantOrig: []int{1, 3, 0, 0, 0},
+		},
+		{
+			name:     "RemoveIf nothing matches",
+			remove:   func(s []int) ([]int, bool) { return RemoveIf(s, func(i int) bool { return i > 9 }) },
+			want:     []int{1, 2, 3, 2, 4},
+			wantOK:   false,
+			wantOrig: []int{1, 2, 3, 2, 4},
+		},
+		{
+			name:     "RemoveDuplicates",
+			remove:   RemoveDuplicates[int],
+			want:     []int{1, 2, 3, 4},
+			wantOK:   true,
+			wantOrig: []int{1, 2, 3, 4, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orig := []int{1, 2, 3, 2, 4}
+			got, ok := tt.remove(orig)
+
+			if ok != tt.wantOK {
+				t.Errorf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("result = %v, want %v", got, tt.want)
+			}
+			if len(orig) != 5 {
+				t.Errorf("len(original) = %d, want 5", len(orig))
+			}
+			if !slices.Equal(orig, tt.wantOrig) {
+				t.Errorf("original = %v, want %v", orig, tt.wantOrig)
+			}
+			if len(got) > 0 && &got[0] != &orig[0] {
+				t.Error("result does not share the backing array of the original slice")
+			}
+		})
+	}
+}
+
+func TestRemoveClearsPointers(t *testing.T) {
+	a, b, c := new(int), new(int), new(int)
+	orig := []*int{a, b, c}
+
+	got, ok := Remove(orig, a)
+	if !ok || len(got) != 2 || got[0] != b || got[1] != c {
+		t.Fatalf("Remove = %v, %v, want [b c], true", got, ok)
+	}
+	if orig[2] != nil {
+		t.Error("tail of the original slice was not cleared")
+	}
+}
+
+func TestRemoveEmpty(t *testing.T) {
+	var empty []int
+	if got, ok := Remove(empty, 1); ok || got != nil {
+		t.Errorf("Remove(nil) = %v, %v, want nil, false", got, ok)
+	}
+	if got, ok := RemoveAt(empty, 0); ok || got != nil {
+		t.Errorf("RemoveAt(nil, 0) = %v, %v, want nil, false", got, ok)
+	}
+	if got, ok := RemoveRange(empty, 0, 0); ok || got != nil {
+		t.Errorf("RemoveRange(nil, 0, 0) = %v, %v, want nil, false", got, ok)
+	}
+	if got, ok := RemoveDuplicates(empty); ok || got != nil {
+		t.Errorf("RemoveDuplicates(nil) = %v, %v, want nil, false", got, ok)
+	}
+}
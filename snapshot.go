@@ -0,0 +1,83 @@
+package devtoolkit
+
+import (
+	"errors"
+	"maps"
+)
+
+// Snapshot is a captured state that can be restored.
+type Snapshot interface {
+	// Restore brings the captured component back to the state it had when the snapshot was taken.
+	Restore() error
+}
+
+// Snapshotter is implemented by components whose in-memory state can be captured and restored,
+// allowing deterministic tests of code that mutates shared state (caches, flags, config).
+type Snapshotter interface {
+	// Snapshot captures the current state.
+	Snapshot() (Snapshot, error)
+}
+
+// RestoreFn is a function adapter implementing Snapshot.
+type RestoreFn func() error
+
+// Restore calls f.
+func (f RestoreFn) Restore() error {
+	return f()
+}
+
+// SnapshotterFn is a function adapter implementing Snapshotter.
+type SnapshotterFn func() (Snapshot, error)
+
+// Snapshot calls f.
+func (f SnapshotterFn) Snapshot() (Snapshot, error) {
+	return f()
+}
+
+// ValueSnapshotter returns a Snapshotter that captures a shallow copy of the value pointed by ptr,
+// e.g. a loaded prop struct, and restores it by assigning the copy back.
+func ValueSnapshotter[T any](ptr *T) Snapshotter {
+	return SnapshotterFn(func() (Snapshot, error) {
+		var captured = *ptr
+		return RestoreFn(func() error {
+			*ptr = captured
+			return nil
+		}), nil
+	})
+}
+
+// MapSnapshotter returns a Snapshotter that captures the entries of m and restores them in place,
+// removing entries added after the snapshot was taken.
+func MapSnapshotter[K comparable, V any](m map[K]V) Snapshotter {
+	return SnapshotterFn(func() (Snapshot, error) {
+		var captured = maps.Clone(m)
+		return RestoreFn(func() error {
+			clear(m)
+			maps.Copy(m, captured)
+			return nil
+		}), nil
+	})
+}
+
+// CaptureSnapshots captures the state of all the given snapshotters and returns a Snapshot that restores
+// them in reverse order. Every restore is attempted and all errors are joined.
+func CaptureSnapshots(snapshotters ...Snapshotter) (Snapshot, error) {
+	var snapshots = make([]Snapshot, 0, len(snapshotters))
+	for _, s := range snapshotters {
+		snapshot, err := s.Snapshot()
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return RestoreFn(func() error {
+		var errs []error
+		for i := len(snapshots) - 1; i >= 0; i-- {
+			if err := snapshots[i].Restore(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	}), nil
+}
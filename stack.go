@@ -0,0 +1,63 @@
+package devtoolkit
+
+// Stack is a generic LIFO container with an optional bounded capacity.
+// The zero value is an empty unbounded stack ready to use. A Stack is not safe for concurrent use.
+type Stack[T any] struct {
+	items    []T
+	capacity int
+}
+
+// NewStack returns a new Stack holding at most 'capacity' items, unbounded if capacity is not positive.
+func NewStack[T any](capacity int) *Stack[T] {
+	return &Stack[T]{capacity: max(capacity, 0)}
+}
+
+// Push adds item to the top of the stack.
+// Returns false if the stack is full.
+func (s *Stack[T]) Push(item T) bool {
+	if s.IsFull() {
+		return false
+	}
+	s.items = append(s.items, item)
+	return true
+}
+
+// Pop removes and returns the item at the top of the stack.
+// Returns false if the stack is empty.
+func (s *Stack[T]) Pop() (T, bool) {
+	var zero T
+	if len(s.items) == 0 {
+		return zero, false
+	}
+
+	last := len(s.items) - 1
+	item := s.items[last]
+	s.items[last] = zero
+	s.items = s.items[:last]
+	return item, true
+}
+
+// Peek returns the item at the top of the stack without removing it.
+// Returns false if the stack is empty.
+func (s *Stack[T]) Peek() (T, bool) {
+	if len(s.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.items[len(s.items)-1], true
+}
+
+// Len returns the number of items in the stack.
+func (s *Stack[T]) Len() int {
+	return len(s.items)
+}
+
+// IsEmpty returns true if the stack has no items.
+func (s *Stack[T]) IsEmpty() bool {
+	return len(s.items) == 0
+}
+
+// IsFull returns true if the stack is bounded and holds its maximum number of items.
+func (s *Stack[T]) IsFull() bool {
+	return s.capacity > 0 && len(s.items) >= s.capacity
+}
@@ -0,0 +1,139 @@
+package devtoolkit
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// FieldChange describes a field that differs between two structs.
+// Path is the path of the field, made of field names, slice indexes and map keys, e.g. 'Address.Lines[1]' or 'Labels.env'.
+type FieldChange struct {
+	Path string
+	Old  any
+	New  any
+}
+
+// StructDiff compares two structs, or pointers to structs, of the same type and returns the fields that differ,
+// walking nested structs, pointers, slices of the same length and maps. Slices of different lengths are reported as a
+// single change, as are a nil and a non-nil pointer. Values with an Equal method, such as time.Time, are compared
+// with it, and the rest with reflect.DeepEqual. Unexported fields are ignored.
+// Returns an error wrapping ErrNotStruct if a or b are not structs, or an error if their types differ.
+func StructDiff(a, b any) ([]FieldChange, error) {
+	av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !av.IsValid() || !bv.IsValid() {
+		return nil, fmt.Errorf("%w: nil value", ErrNotStruct)
+	}
+	if av.Type() != bv.Type() {
+		return nil, fmt.Errorf("cannot diff different types %T and %T", a, b)
+	}
+
+	for av.Kind() == reflect.Pointer && !av.IsNil() && !bv.IsNil() {
+		av, bv = av.Elem(), bv.Elem()
+	}
+	if t := av.Type(); t.Kind() != reflect.Struct && (t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Struct) {
+		return nil, fmt.Errorf("%w: %T", ErrNotStruct, a)
+	}
+
+	var changes []FieldChange
+	diffValues(av, bv, "", &changes)
+	return changes, nil
+}
+
+// diffValues appends the differences between a and b, of the same type, to changes.
+func diffValues(a, b reflect.Value, path string, changes *[]FieldChange) {
+	if equal, ok := equalByMethod(a, b); ok {
+		if !equal {
+			*changes = append(*changes, FieldChange{Path: path, Old: a.Interface(), New: b.Interface()})
+		}
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Struct:
+		if !hasExportedFields(a.Type()) {
+			break
+		}
+		for i := 0; i < a.NumField(); i++ {
+			field := a.Type().Field(i)
+			if field.IsExported() {
+				diffValues(a.Field(i), b.Field(i), joinFieldPath(path, field.Name), changes)
+			}
+		}
+		return
+
+	case reflect.Pointer:
+		if !a.IsNil() && !b.IsNil() {
+			diffValues(a.Elem(), b.Elem(), path, changes)
+			return
+		}
+
+	case reflect.Slice, reflect.Array:
+		if a.Len() == b.Len() && (a.Kind() == reflect.Array || a.IsNil() == b.IsNil()) {
+			for i := 0; i < a.Len(); i++ {
+				diffValues(a.Index(i), b.Index(i), fmt.Sprintf("%s[%d]", path, i), changes)
+			}
+			return
+		}
+
+	case reflect.Map:
+		if !a.IsNil() && !b.IsNil() {
+			diffMaps(a, b, path, changes)
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+		*changes = append(*changes, FieldChange{Path: path, Old: a.Interface(), New: b.Interface()})
+	}
+}
+
+// diffMaps appends the differences between the maps a and b to changes, sorted by key.
+// Keys present in a single map are reported with a nil Old or New value.
+func diffMaps(a, b reflect.Value, path string, changes *[]FieldChange) {
+	var keys = make(map[string]reflect.Value)
+	for _, k := range append(a.MapKeys(), b.MapKeys()...) {
+		keys[fmt.Sprint(k.Interface())] = k
+	}
+
+	var names = MapKeys(keys)
+	sort.Strings(names)
+	for _, name := range names {
+		k := keys[name]
+		av, bv := a.MapIndex(k), b.MapIndex(k)
+		keyPath := joinFieldPath(path, name)
+		switch {
+		case !av.IsValid():
+			*changes = append(*changes, FieldChange{Path: keyPath, New: bv.Interface()})
+		case !bv.IsValid():
+			*changes = append(*changes, FieldChange{Path: keyPath, Old: av.Interface()})
+		default:
+			diffValues(av, bv, keyPath, changes)
+		}
+	}
+}
+
+// equalByMethod compares a and b with their 'Equal(T) bool' method, if any.
+func equalByMethod(a, b reflect.Value) (bool, bool) {
+	method := a.MethodByName("Equal")
+	if !method.IsValid() {
+		return false, false
+	}
+
+	t := method.Type()
+	if t.NumIn() != 1 || t.In(0) != a.Type() || t.NumOut() != 1 || t.Out(0).Kind() != reflect.Bool {
+		return false, false
+	}
+	if a.Kind() == reflect.Pointer && (a.IsNil() || b.IsNil()) {
+		return false, false
+	}
+	return method.Call([]reflect.Value{b})[0].Bool(), true
+}
+
+// joinFieldPath appends name to path with a dot.
+func joinFieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,177 @@
+package devtoolkit
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrNotStruct is returned by StructToMapWithOptions when the value is not a struct or a non-nil pointer to a struct.
+var ErrNotStruct = errors.New("value is not a struct")
+
+// StructMapOptions holds options for StructToMapWithOptions.
+type StructMapOptions struct {
+	// Tag indicates the struct tag used for the keys (e.g. "json", "yaml" or a custom one), in the 'name,omitempty' format.
+	// Fields without the tag use their name and fields tagged '-' are skipped. Default is "json".
+	// If empty, the field names are used.
+	Tag string
+
+	// Flatten indicates whether nested structs are flattened into dot-separated keys (e.g. 'db.host')
+	// instead of nested maps. Default is false.
+	Flatten bool
+
+	// OmitZero indicates whether fields holding their zero value are omitted. Default is false,
+	// so only fields tagged 'omitempty' are omitted, when empty.
+	OmitZero bool
+}
+
+// StructToMapWithOptions converts a struct, or a pointer to a struct, to a map[string]any using reflection.
+// Unlike StructToMap, values keep their types (e.g. ints are not converted to float64) and there is no JSON round trip.
+// Nested structs, and pointers to them, are converted to nested maps or flattened; other values, including slices,
+// maps and structs without exported fields such as time.Time, are kept as they are. Unexported fields are ignored and
+// the fields of exported embedded structs without a tag name are promoted, as in encoding/json.
+// Returns an error, as encoding/json does, if pointers to structs form a cycle.
+func StructToMapWithOptions(t any, optFns ...func(*StructMapOptions)) (map[string]any, error) {
+	var opts = &StructMapOptions{Tag: "json"}
+	for _, fn := range optFns {
+		fn(opts)
+	}
+
+	v := reflect.ValueOf(t)
+	if v.Kind() != reflect.Pointer && v.Kind() != reflect.Struct {
+		return nil, ErrNotStruct
+	}
+
+	var sm = &structMapper{opts: opts, visiting: make(map[structMapPtr]struct{})}
+	var m = make(map[string]any)
+	if err := sm.nested(v, "", m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// structMapPtr identifies a pointer followed by structMapper.
+type structMapPtr struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// structMapper converts structs to maps, tracking the pointers being converted to detect cycles.
+type structMapper struct {
+	opts     *StructMapOptions
+	visiting map[structMapPtr]struct{}
+}
+
+// nested writes the fields of the struct held by v, directly or through non-nil pointers, into m.
+// Returns ErrNotStruct if v holds no struct, or an error if one of the pointers is already being converted.
+func (sm *structMapper) nested(v reflect.Value, prefix string, m map[string]any) error {
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		key := structMapPtr{ptr: v.Pointer(), typ: v.Type()}
+		if _, ok := sm.visiting[key]; ok {
+			return fmt.Errorf("encountered a cycle via %s", v.Type())
+		}
+		sm.visiting[key] = struct{}{}
+		defer delete(sm.visiting, key)
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return ErrNotStruct
+	}
+	return sm.structToMap(v, prefix, m)
+}
+
+// structToMap writes the fields of the struct v into m, with keys prefixed by prefix when flattening.
+func (sm *structMapper) structToMap(v reflect.Value, prefix string, m map[string]any) error {
+	var opts = sm.opts
+	var t = v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, omitEmpty, skip := structMapFieldName(field, opts.Tag)
+		if skip {
+			continue
+		}
+
+		fv := v.Field(i)
+		if opts.OmitZero && fv.IsZero() || omitEmpty && isEmptyValue(fv) {
+			continue
+		}
+
+		_, isStruct := structValue(fv)
+		if field.Anonymous && name == "" && isStruct {
+			if err := sm.nested(fv, prefix, m); err != nil {
+				return err
+			}
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		switch {
+		case isStruct && opts.Flatten:
+			if err := sm.nested(fv, prefix+name+".", m); err != nil {
+				return err
+			}
+		case isStruct:
+			var child = make(map[string]any)
+			if err := sm.nested(fv, "", child); err != nil {
+				return err
+			}
+			m[prefix+name] = child
+		default:
+			m[prefix+name] = fv.Interface()
+		}
+	}
+	return nil
+}
+
+// structMapFieldName returns the name given to field by tag, empty if none, whether the field is tagged 'omitempty'
+// and whether it must be skipped.
+func structMapFieldName(field reflect.StructField, tag string) (string, bool, bool) {
+	if tag == "" {
+		return "", false, false
+	}
+
+	value, ok := field.Tag.Lookup(tag)
+	if !ok {
+		return "", false, false
+	}
+	if value == "-" {
+		return "", false, true
+	}
+
+	name, options, _ := strings.Cut(value, ",")
+	return name, Contains(strings.Split(options, ","), "omitempty"), false
+}
+
+// structValue returns the struct held by v, directly or through non-nil pointers, if it has exported fields.
+func structValue(v reflect.Value) (reflect.Value, bool) {
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct || !hasExportedFields(v.Type()) {
+		return v, false
+	}
+	return v, true
+}
+
+// isEmptyValue reports whether v is empty as defined by the 'omitempty' option of encoding/json.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	default:
+		return false
+	}
+}
@@ -0,0 +1,39 @@
+// Package testutil provides test helpers built on the devtoolkit snapshots, kept apart from the devtoolkit package
+// so that binaries using it don't link the testing package.
+package testutil
+
+import (
+	"github.com/rendis/devtoolkit"
+	"os"
+	"testing"
+)
+
+// EnvSandbox allows tests to set and unset environment variables, restoring the whole environment
+// when the test finishes. Like testing.T.Setenv, it affects the whole process and must not be used
+// in parallel tests.
+type EnvSandbox struct {
+	t testing.TB
+}
+
+// NewEnvSandbox snapshots the current environment variables and restores them when the test finishes.
+func NewEnvSandbox(t testing.TB) *EnvSandbox {
+	t.Helper()
+	SnapshotForTest(t, devtoolkit.EnvSnapshotter())
+	return &EnvSandbox{t: t}
+}
+
+// Set sets the environment variable key to value. The test fails if it cannot be set.
+func (s *EnvSandbox) Set(key, value string) {
+	s.t.Helper()
+	if err := os.Setenv(key, value); err != nil {
+		s.t.Fatalf("error setting env var '%s': %v", key, err)
+	}
+}
+
+// Unset unsets the environment variable key. The test fails if it cannot be unset.
+func (s *EnvSandbox) Unset(key string) {
+	s.t.Helper()
+	if err := os.Unsetenv(key); err != nil {
+		s.t.Fatalf("error unsetting env var '%s': %v", key, err)
+	}
+}
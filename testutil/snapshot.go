@@ -0,0 +1,22 @@
+package testutil
+
+import (
+	"github.com/rendis/devtoolkit"
+	"testing"
+)
+
+// SnapshotForTest captures the state of all the given snapshotters and restores it when the test finishes.
+// The test fails if the state cannot be captured or restored.
+func SnapshotForTest(t testing.TB, snapshotters ...devtoolkit.Snapshotter) {
+	t.Helper()
+	snapshot, err := devtoolkit.CaptureSnapshots(snapshotters...)
+	if err != nil {
+		t.Fatalf("error capturing snapshot: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if err := snapshot.Restore(); err != nil {
+			t.Errorf("error restoring snapshot: %v", err)
+		}
+	})
+}
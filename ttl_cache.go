@@ -0,0 +1,230 @@
+package devtoolkit
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+)
+
+// TTLCacheOptions holds the options of a TTLCache.
+type TTLCacheOptions struct {
+	DefaultTTL      time.Duration // indicates the TTL of the entries stored with Set and GetOrLoad. Zero means no expiration.
+	CleanupInterval time.Duration // indicates how often a janitor goroutine removes the expired entries. Zero means no janitor; expired entries are removed when accessed.
+}
+
+// TTLCache is an in-memory cache whose entries expire after a time to live, set per entry or by default.
+// Expired entries are never returned; they are removed when accessed or, if enabled, by a background janitor.
+// A TTLCache is safe for concurrent use. It implements Snapshotter, so tests can restore its contents.
+type TTLCache[K comparable, V any] struct {
+	options  TTLCacheOptions
+	entries  map[K]ttlEntry[V]
+	loads    map[K]*ttlLoad[V]
+	mu       sync.RWMutex
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+type ttlEntry[V any] struct {
+	value     V
+	expiresAt time.Time // zero if the entry never expires
+}
+
+// ttlLoad is an in-flight GetOrLoad call, shared by the concurrent calls for the same key.
+type ttlLoad[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// NewTTLCache returns a new empty TTLCache with the provided options or defaults,
+// starting the janitor goroutine if options.CleanupInterval is positive. Call Stop to release it.
+func NewTTLCache[K comparable, V any](options *TTLCacheOptions) *TTLCache[K, V] {
+	if options == nil {
+		options = &TTLCacheOptions{}
+	}
+
+	var c = &TTLCache[K, V]{
+		options: *options,
+		entries: make(map[K]ttlEntry[V]),
+		loads:   make(map[K]*ttlLoad[V]),
+		stop:    make(chan struct{}),
+	}
+	if options.CleanupInterval > 0 {
+		go c.janitor(options.CleanupInterval)
+	}
+	return c
+}
+
+// NewTTLCacheWithOptions is like NewTTLCache, with the defaults modified by the given option functions,
+// e.g. NewTTLCacheWithOptions[string, int](WithDefaultTTL(time.Minute)).
+func NewTTLCacheWithOptions[K comparable, V any](optFns ...func(*TTLCacheOptions)) *TTLCache[K, V] {
+	var options = &TTLCacheOptions{}
+	for _, fn := range optFns {
+		fn(options)
+	}
+	return NewTTLCache[K, V](options)
+}
+
+// WithDefaultTTL sets TTLCacheOptions.DefaultTTL.
+func WithDefaultTTL(ttl time.Duration) func(*TTLCacheOptions) {
+	return func(o *TTLCacheOptions) {
+		o.DefaultTTL = ttl
+	}
+}
+
+// WithCleanupInterval sets TTLCacheOptions.CleanupInterval.
+func WithCleanupInterval(interval time.Duration) func(*TTLCacheOptions) {
+	return func(o *TTLCacheOptions) {
+		o.CleanupInterval = interval
+	}
+}
+
+// Set stores value for key with the default TTL.
+func (c *TTLCache[K, V]) Set(key K, value V) {
+	c.SetWithTTL(key, value, c.options.DefaultTTL)
+}
+
+// SetWithTTL stores value for key, expiring after ttl (never if ttl is zero).
+func (c *TTLCache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	var entry = ttlEntry[V]{value: value}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = entry
+}
+
+// Get returns the value of key and true, or the zero value and false if key is not present or expired.
+func (c *TTLCache[K, V]) Get(key K) (V, bool) {
+	c.mu.RLock()
+	entry, ok := c.entries[key]
+	c.mu.RUnlock()
+
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	if entry.expired(time.Now()) {
+		c.deleteExpired(key)
+		var zero V
+		return zero, false
+	}
+	return entry.value, true
+}
+
+// GetOrLoad returns the value of key if present, or loads it with loader and stores it with the default TTL.
+// Concurrent calls for the same key share a single loader call. Errors are returned and not cached.
+// If loader panics, the panic is propagated to the caller and the concurrent calls get an error wrapping a *PanicReport.
+func (c *TTLCache[K, V]) GetOrLoad(key K, loader func(K) (V, error)) (V, error) {
+	if v, ok := c.Get(key); ok {
+		return v, nil
+	}
+
+	c.mu.Lock()
+	if load, ok := c.loads[key]; ok {
+		c.mu.Unlock()
+		<-load.done
+		return load.value, load.err
+	}
+	var load = &ttlLoad[V]{done: make(chan struct{})}
+	c.loads[key] = load
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.loads, key)
+		c.mu.Unlock()
+		close(load.done)
+	}()
+
+	// if loader panics, the concurrent calls waiting for it fail instead of getting a zero value.
+	defer func() {
+		if r := recover(); r != nil {
+			load.err = fmt.Errorf("ttl cache loader: %w", NewPanicReport(context.Background(), r))
+			panic(r)
+		}
+	}()
+
+	load.value, load.err = loader(key)
+	if load.err == nil {
+		c.Set(key, load.value)
+	}
+	return load.value, load.err
+}
+
+// Delete removes key from the cache.
+func (c *TTLCache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+// Len returns the number of entries in the cache, including the expired ones not removed yet.
+func (c *TTLCache[K, V]) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.entries)
+}
+
+// DeleteExpired removes all the expired entries.
+func (c *TTLCache[K, V]) DeleteExpired() {
+	var now = time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, e := range c.entries {
+		if e.expired(now) {
+			delete(c.entries, k)
+		}
+	}
+}
+
+// Stop stops the janitor goroutine, if any. The cache remains usable. Calling Stop more than once is safe.
+func (c *TTLCache[K, V]) Stop() {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+	})
+}
+
+// Snapshot captures the entries of the cache, restored with their original expiration times.
+func (c *TTLCache[K, V]) Snapshot() (Snapshot, error) {
+	c.mu.RLock()
+	var captured = maps.Clone(c.entries)
+	c.mu.RUnlock()
+
+	return RestoreFn(func() error {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.entries = maps.Clone(captured)
+		return nil
+	}), nil
+}
+
+func (c *TTLCache[K, V]) deleteExpired(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// re-check, the entry may have been replaced since it was read.
+	if e, ok := c.entries[key]; ok && e.expired(time.Now()) {
+		delete(c.entries, key)
+	}
+}
+
+func (c *TTLCache[K, V]) janitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			c.DeleteExpired()
+		}
+	}
+}
+
+func (e ttlEntry[V]) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && now.After(e.expiresAt)
+}
@@ -0,0 +1,215 @@
+package devtoolkit
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	ErrInvalidByteSize = errors.New("invalid byte size")
+	ErrInvalidDuration = errors.New("invalid duration")
+)
+
+const (
+	day  = 24 * time.Hour
+	week = 7 * day
+)
+
+// byteUnits maps the (lower-cased) unit suffixes accepted by ParseBytes to their multiplier.
+// Decimal units (KB, MB...) use powers of 1000, binary units (KiB, MiB...) use powers of 1024.
+var byteUnits = map[string]float64{
+	"":    1,
+	"b":   1,
+	"k":   1 << 10,
+	"kb":  1e3,
+	"kib": 1 << 10,
+	"m":   1 << 20,
+	"mb":  1e6,
+	"mib": 1 << 20,
+	"g":   1 << 30,
+	"gb":  1e9,
+	"gib": 1 << 30,
+	"t":   1 << 40,
+	"tb":  1e12,
+	"tib": 1 << 40,
+	"p":   1 << 50,
+	"pb":  1e15,
+	"pib": 1 << 50,
+	"e":   1 << 60,
+	"eb":  1e18,
+	"eib": 1 << 60,
+}
+
+var binaryByteSuffixes = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+// ParseBytes parses a human-readable byte size such as "512", "10KB", "1.5GiB" or "2 mib" into a number of bytes.
+// Units are case-insensitive. Decimal units (KB, MB, GB...) are powers of 1000, binary units (KiB, MiB, GiB...)
+// and single-letter units (K, M, G...) are powers of 1024.
+func ParseBytes(s string) (int64, error) {
+	str := strings.TrimSpace(s)
+	idx := strings.IndexFunc(str, func(r rune) bool {
+		return !unicode.IsDigit(r) && r != '.'
+	})
+	if idx == -1 {
+		idx = len(str)
+	}
+
+	numStr, unitStr := str[:idx], strings.ToLower(strings.TrimSpace(str[idx:]))
+	if numStr == "" {
+		return 0, fmt.Errorf("%w: '%s'", ErrInvalidByteSize, s)
+	}
+
+	num, err := strconv.ParseFloat(numStr, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: '%s'", ErrInvalidByteSize, s)
+	}
+
+	multiplier, ok := byteUnits[unitStr]
+	if !ok {
+		return 0, fmt.Errorf("%w: unknown unit '%s' in '%s'", ErrInvalidByteSize, unitStr, s)
+	}
+
+	bytes := num * multiplier
+	if bytes >= math.MaxInt64 {
+		return 0, fmt.Errorf("%w: '%s' overflows int64", ErrInvalidByteSize, s)
+	}
+	return int64(bytes), nil
+}
+
+// FormatBytes formats a number of bytes as a human-readable string using binary units, e.g. 1536 -> "1.5KiB".
+// The result can be parsed back with ParseBytes.
+func FormatBytes(bytes int64) string {
+	var sign string
+	value := float64(bytes)
+	if bytes < 0 {
+		sign = "-"
+		value = -value
+	}
+
+	unit := 0
+	for value >= 1024 && unit < len(binaryByteSuffixes)-1 {
+		value /= 1024
+		unit++
+	}
+
+	// rounding may reach the next unit, e.g. 1MiB-1 -> 1024KiB
+	value = math.Round(value*100) / 100
+	if value >= 1024 && unit < len(binaryByteSuffixes)-1 {
+		value = math.Round(value/1024*100) / 100
+		unit++
+	}
+
+	formatted := strconv.FormatFloat(value, 'f', -1, 64)
+	return sign + formatted + binaryByteSuffixes[unit]
+}
+
+// ParseDurationExtended parses a duration string like time.ParseDuration, additionally accepting
+// the units "d" (24h) and "w" (7d), e.g. "1d2h", "2w3d" or "-1d12h30m".
+func ParseDurationExtended(s string) (time.Duration, error) {
+	str := strings.TrimSpace(s)
+	if str == "" {
+		return 0, fmt.Errorf("%w: empty string", ErrInvalidDuration)
+	}
+
+	var negative bool
+	if str[0] == '-' || str[0] == '+' {
+		negative = str[0] == '-'
+		str = str[1:]
+	}
+
+	if str == "0" {
+		return 0, nil
+	}
+
+	var total time.Duration
+	for str != "" {
+		numEnd := strings.IndexFunc(str, func(r rune) bool {
+			return !unicode.IsDigit(r) && r != '.'
+		})
+		if numEnd <= 0 {
+			return 0, fmt.Errorf("%w: '%s'", ErrInvalidDuration, s)
+		}
+
+		unitEnd := strings.IndexFunc(str[numEnd:], func(r rune) bool {
+			return unicode.IsDigit(r) || r == '.'
+		})
+		if unitEnd == -1 {
+			unitEnd = len(str)
+		} else {
+			unitEnd += numEnd
+		}
+
+		num, unit := str[:numEnd], str[numEnd:unitEnd]
+		str = str[unitEnd:]
+
+		var part time.Duration
+		switch unit {
+		case "d", "w":
+			value, err := strconv.ParseFloat(num, 64)
+			if err != nil {
+				return 0, fmt.Errorf("%w: '%s'", ErrInvalidDuration, s)
+			}
+			// converting an out-of-range float to an integer is implementation-defined, so check the bounds first.
+			nanos := value * float64(IfThenElse(unit == "d", day, week))
+			if nanos >= math.MaxInt64 || nanos <= math.MinInt64 {
+				return 0, fmt.Errorf("%w: '%s' overflows", ErrInvalidDuration, s)
+			}
+			part = time.Duration(nanos)
+		default:
+			var err error
+			if part, err = time.ParseDuration(num + unit); err != nil {
+				return 0, fmt.Errorf("%w: '%s'", ErrInvalidDuration, s)
+			}
+		}
+
+		if part < 0 || total > math.MaxInt64-part {
+			return 0, fmt.Errorf("%w: '%s' overflows", ErrInvalidDuration, s)
+		}
+		total += part
+	}
+
+	if negative {
+		total = -total
+	}
+	return total, nil
+}
+
+// FormatDurationShort formats a duration in a compact form using days, hours, minutes and seconds,
+// omitting zero components, e.g. 26h3m -> "1d2h3m". Sub-second precision is dropped for durations
+// of one second or more; shorter durations are formatted with time.Duration.String.
+// The result can be parsed back with ParseDurationExtended.
+func FormatDurationShort(d time.Duration) string {
+	var sign string
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+
+	if d < time.Second {
+		return sign + d.String()
+	}
+
+	var sb strings.Builder
+	sb.WriteString(sign)
+	for _, u := range []struct {
+		unit   time.Duration
+		suffix string
+	}{
+		{day, "d"},
+		{time.Hour, "h"},
+		{time.Minute, "m"},
+		{time.Second, "s"},
+	} {
+		if n := d / u.unit; n > 0 {
+			sb.WriteString(strconv.FormatInt(int64(n), 10))
+			sb.WriteString(u.suffix)
+			d -= n * u.unit
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,106 @@
+package devtoolkit
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// validateSliceConcurrencyThreshold is the number of items from which ValidateSlice validates concurrently.
+const validateSliceConcurrencyThreshold = 1000
+
+// ValidationReport summarizes the validation of a slice of items.
+type ValidationReport struct {
+	Total   int           // number of validated items
+	Valid   int           // number of valid items
+	Invalid int           // number of invalid items
+	Errors  map[int]error // validation error of each invalid item, by index
+}
+
+// ValidateSlice validates each item of items with the 'validate' struct tags, including the custom validators
+// available to LoadPropFile, and returns the validation errors aligned with the items (nil for valid items).
+// Items must be structs or pointers to structs. Large slices are validated concurrently with ConcurrentWorkers.
+func ValidateSlice[T any](items []T) []error {
+	var errs = make([]error, len(items))
+	if len(items) == 0 {
+		return errs
+	}
+
+	var validate = newValidator()
+	var validateRange = func(start, end int) {
+		for i := start; i < end; i++ {
+			errs[i] = validate.Struct(items[i])
+		}
+	}
+
+	if len(items) < validateSliceConcurrencyThreshold {
+		validateRange(0, len(items))
+		return errs
+	}
+
+	// validate chunks concurrently, each worker writing only the errors of its own chunk.
+	var workers = runtime.GOMAXPROCS(0)
+	var chunkSize = (len(items) + workers - 1) / workers
+	var cw = NewConcurrentWorkers(workers)
+	for start := 0; start < len(items); start += chunkSize {
+		end := min(start+chunkSize, len(items))
+		cw.Execute(func() {
+			validateRange(start, end)
+		})
+	}
+	cw.Wait()
+	return errs
+}
+
+// ValidateSliceReport validates items like ValidateSlice and returns a summarized report.
+func ValidateSliceReport[T any](items []T) ValidationReport {
+	return NewValidationReport(ValidateSlice(items))
+}
+
+// NewValidationReport summarizes index-aligned validation errors, such as the ones returned by ValidateSlice.
+func NewValidationReport(errs []error) ValidationReport {
+	var report = ValidationReport{Total: len(errs), Errors: make(map[int]error)}
+	for i, err := range errs {
+		if err != nil {
+			report.Errors[i] = err
+		}
+	}
+	report.Invalid = len(report.Errors)
+	report.Valid = report.Total - report.Invalid
+	return report
+}
+
+// OK returns true if all the items are valid.
+func (r ValidationReport) OK() bool {
+	return r.Invalid == 0
+}
+
+// Err returns the join of the errors of the invalid items, prefixed with their index, in index order,
+// or nil if all the items are valid.
+func (r ValidationReport) Err() error {
+	if r.OK() {
+		return nil
+	}
+
+	var indexes = MapKeys(r.Errors)
+	slices.Sort(indexes)
+
+	var errs = make([]error, 0, len(indexes))
+	for _, i := range indexes {
+		errs = append(errs, fmt.Errorf("item %d: %w", i, r.Errors[i]))
+	}
+	return errors.Join(errs...)
+}
+
+// String returns a summary of the report followed by the errors of the invalid items.
+func (r ValidationReport) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d items validated: %d valid, %d invalid", r.Total, r.Valid, r.Invalid)
+	if err := r.Err(); err != nil {
+		sb.WriteString("\n")
+		sb.WriteString(err.Error())
+	}
+	return sb.String()
+}